@@ -0,0 +1,91 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterHPAOverrideSpec defines the desired state of ClusterHPAOverride.
+// +kubebuilder:validation:XValidation:rule="has(self.time) != has(self.schedule)",message="exactly one of time or schedule must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.minReplicas) || has(self.relativeMinReplicas) || self.type == 'ScaleDown'",message="at least one of minReplicas or relativeMinReplicas must be set"
+type ClusterHPAOverrideSpec struct {
+	OverrideSpec `json:",inline"`
+
+	// NamespaceSelector selects the namespaces of the HorizontalPodAutoscalerX
+	// objects to override by their labels. Defaults to all namespaces.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects the HorizontalPodAutoscalerX objects to override by
+	// their labels. Defaults to all HorizontalPodAutoscalerX objects in the
+	// selected namespaces.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ClusterHPAOverrideStatus defines the observed state of ClusterHPAOverride.
+type ClusterHPAOverrideStatus struct {
+	// Active is the active status of the override.
+	// +kubebuilder:validation:Optional
+	Active bool `json:"active,omitempty"`
+
+	// Phase is the lifecycle phase of the override.
+	// +kubebuilder:validation:Optional
+	Phase HPAOverridePhase `json:"phase,omitempty"`
+
+	// StartTime is the scheduled start of the override. It is applied in
+	// full from the lead time before it, after any warm-up.
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time the override stops being applied in full,
+	// before any cool-down.
+	// +kubebuilder:validation:Optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// ObservedGeneration is the generation of the ClusterHPAOverride when it
+	// was last observed.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories=all,shortName=chpao
+// +kubebuilder:printcolumn:name="MinReplicas",type=integer,JSONPath=".spec.minReplicas",description="The minReplicas to override"
+// +kubebuilder:printcolumn:name="MaxReplicas",type=integer,JSONPath=".spec.maxReplicas",description="The maxReplicas to override"
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=".status.active",description="The active status of the override"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase",description="The lifecycle phase of the override"
+
+// ClusterHPAOverride is the Schema for the clusterhpaoverrides API.
+type ClusterHPAOverride struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterHPAOverrideSpec   `json:"spec,omitempty"`
+	Status ClusterHPAOverrideStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterHPAOverrideList contains a list of ClusterHPAOverride.
+type ClusterHPAOverrideList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterHPAOverride `json:"items"`
+}
+
+// AsHPAOverride converts the ClusterHPAOverride to a HPAOverride with the same
+// name and override spec, but without a namespace or a target.
+func (in *ClusterHPAOverride) AsHPAOverride() HPAOverride {
+	return HPAOverride{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              in.Name,
+			CreationTimestamp: in.CreationTimestamp,
+		},
+		Spec: HPAOverrideSpec{OverrideSpec: in.Spec.OverrideSpec},
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterHPAOverride{}, &ClusterHPAOverrideList{})
+}
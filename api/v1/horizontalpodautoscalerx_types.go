@@ -1,10 +1,76 @@
 package v1
 
 import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// FallbackTrigger is a rule over a condition of the HPA that triggers the
+// fallback once the condition has matched for long enough.
+type FallbackTrigger struct {
+	// Type is the type of the HPA condition, e.g. ScalingActive.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=AbleToScale;ScalingActive;ScalingLimited
+	Type autoscalingv2.HorizontalPodAutoscalerConditionType `json:"type"`
+
+	// Status is the status of the HPA condition that triggers the fallback.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason is the reason of the HPA condition that triggers the fallback,
+	// e.g. FailedGetExternalMetric. Any reason matches if unset.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// Duration is the minimum duration since the last transition of the HPA
+	// condition before triggering the fallback.
+	// +kubebuilder:validation:Optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// FallbackTier is an escalation of the fallback once the HPA has been failing
+// for long enough.
+type FallbackTier struct {
+	// MinReplicas is the minReplicas to fallback to in this tier.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas"`
+
+	// After is the duration since the HPA condition of the fired trigger last
+	// transitioned after which this tier applies.
+	// +kubebuilder:validation:Required
+	After metav1.Duration `json:"after"`
+}
+
+// FallbackStepDown defines a gradual release of the fallback.
+type FallbackStepDown struct {
+	// Steps is the number of equal steps from the fallback minReplicas down
+	// to the base minReplicas.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Steps int32 `json:"steps"`
+
+	// Interval is the duration between steps.
+	// +kubebuilder:validation:Required
+	Interval metav1.Duration `json:"interval"`
+}
+
+// FallbackRecovery defines how the fallback is released once the HPA
+// recovers.
+type FallbackRecovery struct {
+	// Duration is how long no fallback trigger may match the HPA conditions
+	// before the fallback is released.
+	// +kubebuilder:validation:Optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// StepDown releases the fallback gradually once Duration has passed,
+	// rather than all at once.
+	// +kubebuilder:validation:Optional
+	StepDown *FallbackStepDown `json:"stepDown,omitempty"`
+}
+
 type Fallback struct {
 	// MinReplicas is the minReplicas to fallback to. The is manifested as
 	// patching the HorizontalPodAutoscaler.spec.minReplicas.
@@ -14,12 +80,97 @@ type Fallback struct {
 	MinReplicas int32 `json:"minReplicas,omitempty"`
 
 	// Duration is the minimum duration to observe a failing condition on the
-	// HPA before triggering a fallback.
+	// HPA before triggering a fallback. Only used if Triggers is unset.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Default=0s
 	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Triggers are the rules over the HPA conditions that trigger the
+	// fallback, the first of which to match for long enough wins. Defaults to
+	// the ScalingActive condition being False for Duration.
+	// +kubebuilder:validation:Optional
+	Triggers []FallbackTrigger `json:"triggers,omitempty"`
+
+	// Tiers escalate the fallback the longer the HPA keeps failing. Once the
+	// fallback is triggered, the last tier whose After has passed applies
+	// instead of MinReplicas. Tiers must be ordered by After.
+	// +kubebuilder:validation:Optional
+	Tiers []FallbackTier `json:"tiers,omitempty"`
+
+	// Recovery holds the fallback after the HPA recovers, to avoid flapping
+	// when it recovers intermittently. The fallback is released as soon as
+	// no trigger fires if unset.
+	// +kubebuilder:validation:Optional
+	Recovery *FallbackRecovery `json:"recovery,omitempty"`
+}
+
+// Mode is whether the HorizontalPodAutoscalerX updates its HPA.
+// +kubebuilder:validation:Enum=Enforce;DryRun
+type Mode string
+
+const (
+	// ModeEnforce updates the HPA.
+	ModeEnforce Mode = "Enforce"
+	// ModeDryRun computes the replicas of the HPA and records them in the
+	// status without updating the HPA.
+	ModeDryRun Mode = "DryRun"
+)
+
+// HPATemplate is the spec of a HorizontalPodAutoscaler created and owned by
+// the HorizontalPodAutoscalerX. Its minReplicas is always managed by the
+// HorizontalPodAutoscalerX.
+type HPATemplate struct {
+	// ScaleTargetRef points to the target resource to scale.
+	// +kubebuilder:validation:Required
+	ScaleTargetRef autoscalingv2.CrossVersionObjectReference `json:"scaleTargetRef"`
+
+	// Metrics contains the specifications used to calculate the desired
+	// replica count.
+	// +kubebuilder:validation:Optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior configures the scaling behavior of the target.
+	// +kubebuilder:validation:Optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas, unless the
+	// HorizontalPodAutoscalerX or an active override sets a maxReplicas.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
 }
 
+// ConflictPolicy is what the HorizontalPodAutoscalerX does when another field
+// manager owns a conflicting minReplicas or maxReplicas of its HPA.
+// +kubebuilder:validation:Enum=Force;BackOff
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyForce takes ownership of the replicas from the other
+	// field manager.
+	ConflictPolicyForce ConflictPolicy = "Force"
+	// ConflictPolicyBackOff leaves the replicas to the other field manager.
+	ConflictPolicyBackOff ConflictPolicy = "BackOff"
+)
+
+// OverrideStrategy is how the HorizontalPodAutoscalerX selects among its
+// active overrides.
+// +kubebuilder:validation:Enum=Max;Min;HighestPriority;LastCreated
+type OverrideStrategy string
+
+const (
+	// OverrideStrategyMax selects the override with the highest minReplicas,
+	// which only ever raises the base minReplicas.
+	OverrideStrategyMax OverrideStrategy = "Max"
+	// OverrideStrategyMin selects the override with the lowest minReplicas.
+	OverrideStrategyMin OverrideStrategy = "Min"
+	// OverrideStrategyHighestPriority selects the override with the highest
+	// priority, then the highest minReplicas.
+	OverrideStrategyHighestPriority OverrideStrategy = "HighestPriority"
+	// OverrideStrategyLastCreated selects the most recently created override.
+	OverrideStrategyLastCreated OverrideStrategy = "LastCreated"
+)
+
 // HorizontalPodAutoscalerXSpec defines the desired state of HorizontalPodAutoscalerX.
 type HorizontalPodAutoscalerXSpec struct {
 	// HPATargetName is the name of the HorizontalPodAutoscaler to scale.
@@ -27,6 +178,12 @@ type HorizontalPodAutoscalerXSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	HPATargetName string `json:"hpaTargetName,omitempty"`
 
+	// HPATemplate is the spec of the HorizontalPodAutoscaler named
+	// HPATargetName, which the HorizontalPodAutoscalerX then creates and
+	// owns. The HorizontalPodAutoscaler is managed externally if unset.
+	// +kubebuilder:validation:Optional
+	HPATemplate *HPATemplate `json:"hpaTemplate,omitempty"`
+
 	// Fallback defines the fallback behavior.
 	// +kubebuilder:validation:Optional
 	Fallback *Fallback `json:"fallback,omitempty"`
@@ -36,6 +193,73 @@ type HorizontalPodAutoscalerXSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Default=1
 	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maxReplicas for the HPA. Active HPAOverrides that set
+	// a maxReplicas take precedence over it. If unset, the HPA keeps the
+	// maxReplicas it had before it was first updated by the
+	// HorizontalPodAutoscalerX.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// FloorMinReplicas is the absolute floor of the minReplicas that no
+	// override may go below. There is no floor if unset.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	FloorMinReplicas *int32 `json:"floorMinReplicas,omitempty"`
+
+	// ReleaseMinReplicas is the minReplicas to set on the HPA when the
+	// HorizontalPodAutoscalerX is deleted. Defaults to the minReplicas the HPA
+	// had before it was first updated by the HorizontalPodAutoscalerX.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ReleaseMinReplicas *int32 `json:"releaseMinReplicas,omitempty"`
+
+	// Mode is whether the HorizontalPodAutoscalerX updates its HPA. In
+	// DryRun mode the replicas the HPA would be updated to are only recorded
+	// in the status, events and metrics.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Enforce
+	Mode Mode `json:"mode,omitempty"`
+
+	// ConflictPolicy is what to do when another field manager, e.g. a GitOps
+	// tool, changes the minReplicas or maxReplicas of the HPA after the
+	// HorizontalPodAutoscalerX adopted it. The HPA is always adopted on the
+	// first update regardless.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Force
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// OverrideStrategy is how to select among the active overrides. With Max
+	// the selected override only raises the base minReplicas. With any other
+	// strategy it replaces the base minReplicas, so it can also lower it, e.g.
+	// to save costs at night. The fallback always applies on top.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Max
+	OverrideStrategy OverrideStrategy `json:"overrideStrategy,omitempty"`
+}
+
+// DryRunStatus is what the HorizontalPodAutoscalerX would update its HPA to
+// if it weren't in DryRun mode.
+type DryRunStatus struct {
+	// MinReplicas is the minReplicas the HPA would be updated to.
+	// +kubebuilder:validation:Required
+	MinReplicas int32 `json:"minReplicas"`
+
+	// MaxReplicas is the maxReplicas the HPA would be updated to.
+	// +kubebuilder:validation:Required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Reason is why the HPA would be updated to MinReplicas.
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+
+	// HPATemplate is what applying the hpaTemplate would do to the HPA: Create
+	// it if it doesn't exist, or Update it if it doesn't match the hpaTemplate.
+	// It is empty if there is no hpaTemplate or the HPA matches it.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Create;Update
+	HPATemplate string `json:"hpaTemplate,omitempty"`
 }
 
 type HorizontalPodAutoscalerXConditionType string
@@ -43,8 +267,18 @@ type HorizontalPodAutoscalerXConditionType string
 const (
 	// ConditionReady indicates that the HorizontalPodAutoscalerX is ready.
 	ConditionReady HorizontalPodAutoscalerXConditionType = "Ready"
-	// ConditionFallback indicates that the HorizontalPodAutoscalerX is in fallback mode.
-	ConditionFallback HorizontalPodAutoscalerXConditionType = "FallbackTriggered"
+	// ConditionFallbackPending indicates that a fallback trigger matches the HPA conditions but hasn't matched for
+	// long enough to fire.
+	ConditionFallbackPending HorizontalPodAutoscalerXConditionType = "FallbackPending"
+	// ConditionFallbackActive indicates that the fallback minReplicas is applied to the HPA.
+	ConditionFallbackActive HorizontalPodAutoscalerXConditionType = "FallbackActive"
+	// ConditionTypeFallbackTriggered indicated that the fallback minReplicas was applied to the HPA. It is removed from
+	// the HorizontalPodAutoscalerX objects that still have it.
+	//
+	// Deprecated: Use ConditionFallbackPending and ConditionFallbackActive instead.
+	ConditionTypeFallbackTriggered HorizontalPodAutoscalerXConditionType = "FallbackTriggered"
+	// ConditionConflict indicates that another field manager owns a conflicting minReplicas or maxReplicas of the HPA.
+	ConditionConflict HorizontalPodAutoscalerXConditionType = "Conflict"
 	// ConditionOverrideActive indicates that an override is actively applied.
 	ConditionOverrideActive HorizontalPodAutoscalerXConditionType = "OverrideActive"
 )
@@ -75,16 +309,180 @@ type HorizontalPodAutoscalerXCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// FallbackRecoveryStatus is the state of the recovery from the fallback.
+type FallbackRecoveryStatus struct {
+	// HealthySince is the time since which no fallback trigger has matched
+	// the HPA conditions, i.e. when the ScalingActive condition of the HPA
+	// became True if it did so after the fallback was engaged.
+	// +kubebuilder:validation:Required
+	HealthySince metav1.Time `json:"healthySince"`
+
+	// FromMinReplicas is the fallback minReplicas the recovery steps down
+	// from.
+	// +kubebuilder:validation:Required
+	FromMinReplicas int32 `json:"fromMinReplicas"`
+}
+
+// CandidateSource is where a candidate minReplicas comes from.
+// +kubebuilder:validation:Enum=BaseMinReplicas;FloorMinReplicas;Fallback;HPAOverride;ClusterHPAOverride
+type CandidateSource string
+
+const (
+	// CandidateSourceBaseMinReplicas is the spec.minReplicas of the HorizontalPodAutoscalerX.
+	CandidateSourceBaseMinReplicas CandidateSource = "BaseMinReplicas"
+	// CandidateSourceFloorMinReplicas is the spec.floorMinReplicas of the HorizontalPodAutoscalerX.
+	CandidateSourceFloorMinReplicas CandidateSource = "FloorMinReplicas"
+	// CandidateSourceFallback is the fallback of the HorizontalPodAutoscalerX.
+	CandidateSourceFallback CandidateSource = "Fallback"
+	// CandidateSourceHPAOverride is an active HPAOverride.
+	CandidateSourceHPAOverride CandidateSource = "HPAOverride"
+	// CandidateSourceClusterHPAOverride is an active ClusterHPAOverride.
+	CandidateSourceClusterHPAOverride CandidateSource = "ClusterHPAOverride"
+)
+
+// MinReplicasCandidate is a candidate for the minReplicas of the HPA.
+type MinReplicasCandidate struct {
+	// Source is where the candidate comes from.
+	// +kubebuilder:validation:Required
+	Source CandidateSource `json:"source"`
+
+	// Name is the name of the override, if the candidate is an override.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// MinReplicas is the minReplicas the candidate suggests.
+	// +kubebuilder:validation:Required
+	MinReplicas int32 `json:"minReplicas"`
+}
+
+// ResolvedOverride is the minReplicas a relative override resolved to for
+// its current window.
+type ResolvedOverride struct {
+	// Name is the namespace/name of the HPAOverride, or the name of the
+	// ClusterHPAOverride.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// StartTime is the start of the window the minReplicas was resolved for.
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// MinReplicas is the resolved minReplicas.
+	// +kubebuilder:validation:Required
+	MinReplicas int32 `json:"minReplicas"`
+}
+
+// OverrideReadiness is whether the scale target of the HPA had the ready
+// replicas requested by an override with a lead time when it started.
+type OverrideReadiness struct {
+	// Name is the namespace/name of the HPAOverride, or the name of the
+	// ClusterHPAOverride.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// StartTime is the scheduled start of the window.
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// MinReplicas is the minReplicas requested by the override.
+	// +kubebuilder:validation:Required
+	MinReplicas int32 `json:"minReplicas"`
+
+	// ReadyReplicas is the number of ready replicas of the scale target at
+	// the start of the window.
+	// +kubebuilder:validation:Required
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// Ready is whether the ready replicas reached the minReplicas.
+	// +kubebuilder:validation:Required
+	Ready bool `json:"ready"`
+}
+
 // HorizontalPodAutoscalerXStatus defines the observed state of HorizontalPodAutoscalerX.
 type HorizontalPodAutoscalerXStatus struct {
 	// Conditions is a list of conditions that apply to the HorizontalPodAutoscalerX.
 	// +kubebuilder:validation:Optional
 	Conditions []HorizontalPodAutoscalerXCondition `json:"conditions,omitempty"`
 
+	// FailureSince is the earliest last transition time of the HPA
+	// conditions that match a fallback trigger, if any match.
+	// +kubebuilder:validation:Optional
+	FailureSince *metav1.Time `json:"failureSince,omitempty"`
+
+	// FallbackEngagedAt is the time the fallback was applied, if it is
+	// applied. It is kept while the HPA recovers from the fallback.
+	// +kubebuilder:validation:Optional
+	FallbackEngagedAt *metav1.Time `json:"fallbackEngagedAt,omitempty"`
+
+	// FallbackTrigger is the fallback trigger that fired, if the fallback is
+	// triggered.
+	// +kubebuilder:validation:Optional
+	FallbackTrigger *FallbackTrigger `json:"fallbackTrigger,omitempty"`
+
+	// FallbackTier is the fallback tier that applies if the fallback is
+	// triggered, 0 for the base fallback and i for the i-th tier.
+	// +kubebuilder:validation:Optional
+	FallbackTier *int32 `json:"fallbackTier,omitempty"`
+
+	// NextFallbackTierTime is the time the next fallback tier applies if the
+	// HPA keeps failing.
+	// +kubebuilder:validation:Optional
+	NextFallbackTierTime *metav1.Time `json:"nextFallbackTierTime,omitempty"`
+
+	// FallbackRecovery is the state of the recovery from the fallback, if
+	// the HPA is recovering.
+	// +kubebuilder:validation:Optional
+	FallbackRecovery *FallbackRecoveryStatus `json:"fallbackRecovery,omitempty"`
+
+	// ResolvedOverrides is the minReplicas resolved by each active relative
+	// override, so that scaling up doesn't compound the override.
+	// +kubebuilder:validation:Optional
+	ResolvedOverrides []ResolvedOverride `json:"resolvedOverrides,omitempty"`
+
+	// OverrideReadiness is whether the scale target was ready on time for
+	// each active override with a lead time that has started.
+	// +kubebuilder:validation:Optional
+	OverrideReadiness []OverrideReadiness `json:"overrideReadiness,omitempty"`
+
+	// StartupLatency is the highest time from creation to ready of the
+	// ready pods of the scale target of the HPA, observed while an override
+	// that derives its lead time from it is within reach of its window. It is
+	// kept until the override ends.
+	// +kubebuilder:validation:Optional
+	StartupLatency *metav1.Duration `json:"startupLatency,omitempty"`
+
 	// ObservedGeneration is the generation of the HorizontalPodAutoscalerX
 	// when it was last observed.
 	// +kubebuilder:validation:Optional
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// MinReplicas is the minReplicas last applied to the HPA.
+	// +kubebuilder:validation:Optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Candidates are the base minReplicas, the floor if it is set, the
+	// fallback if it is engaged, and every active ScaleUp override, the one
+	// selected by the override strategy first, then every active ScaleDown
+	// override, the highest first. The highest candidate is applied, except
+	// that the selected override replaces the base minReplicas with
+	// strategies other than Max, and so does the highest ScaleDown override.
+	// +kubebuilder:validation:Optional
+	Candidates []MinReplicasCandidate `json:"candidates,omitempty"`
+
+	// Winner is the candidate whose minReplicas is applied. Ties are won
+	// by the base minReplicas, then the floor, then the fallback.
+	// +kubebuilder:validation:Optional
+	Winner *MinReplicasCandidate `json:"winner,omitempty"`
+
+	// LastAppliedTime is the last time the minReplicas or maxReplicas of
+	// the HPA were changed.
+	// +kubebuilder:validation:Optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// DryRun is what the HPA would be updated to, if the
+	// HorizontalPodAutoscalerX is in DryRun mode.
+	// +kubebuilder:validation:Optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -92,7 +490,10 @@ type HorizontalPodAutoscalerXStatus struct {
 // +kubebuilder:resource:categories=all,shortName=hpax
 // +kubebuilder:printcolumn:name="HPA",type=string,JSONPath=".spec.hpaTargetName",description="The name of the HorizontalPodAutoscaler to scale"
 // +kubebuilder:printcolumn:name="minReplicas",type=integer,JSONPath=".spec.minReplicas",description="The minReplicas for the HorizontalPodAutoscaler"
+// +kubebuilder:printcolumn:name="maxReplicas",type=integer,JSONPath=".spec.maxReplicas",description="The maxReplicas for the HorizontalPodAutoscaler"
 // +kubebuilder:printcolumn:name="fallback",type=integer,JSONPath=".spec.fallback.minReplicas",description="The minReplicas to fallback to"
+// +kubebuilder:printcolumn:name="effective",type=integer,JSONPath=".status.minReplicas",description="The minReplicas applied to the HorizontalPodAutoscaler"
+// +kubebuilder:printcolumn:name="mode",type=string,JSONPath=".spec.mode",description="Whether the HorizontalPodAutoscaler is updated",priority=1
 
 // HorizontalPodAutoscalerX is the Schema for the horizontalpodautoscalerxes API.
 type HorizontalPodAutoscalerX struct {
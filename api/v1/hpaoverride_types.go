@@ -4,25 +4,205 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// HPAOverrideSpec defines the desired state of HPAOverride.
-type HPAOverrideSpec struct {
-	// MinReplicas is the minReplicas to override.
+// Schedule defines a recurring start time for an override.
+type Schedule struct {
+	// Cron is a standard five field cron expression for the start of each
+	// occurrence of the override, e.g. "45 8 * * 1-5".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron,omitempty"`
+
+	// TimeZone is the IANA time zone the cron expression is evaluated in, e.g.
+	// "Europe/Berlin". Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=CurrentReplicas;DesiredReplicas;BaseMinReplicas
+type RelativeReference string
+
+const (
+	// RelativeReferenceCurrentReplicas is the current replicas of the HPA.
+	RelativeReferenceCurrentReplicas RelativeReference = "CurrentReplicas"
+	// RelativeReferenceDesiredReplicas is the desired replicas of the HPA.
+	RelativeReferenceDesiredReplicas RelativeReference = "DesiredReplicas"
+	// RelativeReferenceBaseMinReplicas is the minReplicas of the HorizontalPodAutoscalerX.
+	RelativeReferenceBaseMinReplicas RelativeReference = "BaseMinReplicas"
+)
+
+// RelativeMinReplicas defines a minReplicas relative to the replicas of the
+// HPA or the HorizontalPodAutoscalerX.
+type RelativeMinReplicas struct {
+	// Reference is the replica count the minReplicas is relative to.
+	// +kubebuilder:validation:Required
+	Reference RelativeReference `json:"reference"`
+
+	// Percent is the minReplicas as a percentage of the reference, rounded
+	// up, e.g. 200 to double it.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:Minimum=0
-	MinReplicas int32 `json:"minReplicas,omitempty"`
+	Percent int32 `json:"percent"`
+}
 
-	// Duration is the duration to apply this override.
+// Ramp defines a gradual change of the minReplicas between the base
+// minReplicas of the HorizontalPodAutoscalerX and the minReplicas of an
+// override.
+type Ramp struct {
+	// Duration is the duration of the ramp.
 	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// Steps is the number of equal steps of the ramp. If unset, the ramp
+	// changes the minReplicas by one replica at a time, in at most 10 steps.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Steps *int32 `json:"steps,omitempty"`
+}
+
+// LeadTime defines how long before its start an override is applied.
+type LeadTime struct {
+	// Duration is a fixed lead time.
+	// +kubebuilder:validation:Optional
 	Duration metav1.Duration `json:"duration,omitempty"`
 
-	// Time is the time to apply this override.
+	// FromStartupLatency adds the startup latency of the pods of the scale
+	// target of each HPA, as observed by its HorizontalPodAutoscalerX, to the
+	// lead time.
+	// +kubebuilder:validation:Optional
+	FromStartupLatency bool `json:"fromStartupLatency,omitempty"`
+}
+
+// OverrideType is whether an override raises or lowers the minReplicas.
+// +kubebuilder:validation:Enum=ScaleUp;ScaleDown
+type OverrideType string
+
+const (
+	// OverrideTypeScaleUp raises the minReplicas above the base minReplicas.
+	OverrideTypeScaleUp OverrideType = "ScaleUp"
+	// OverrideTypeScaleDown replaces the base minReplicas, so it can lower it
+	// down to the floorMinReplicas of the HorizontalPodAutoscalerX.
+	OverrideTypeScaleDown OverrideType = "ScaleDown"
+)
+
+// OverrideSpec defines the desired state shared by HPAOverride and
+// ClusterHPAOverride.
+type OverrideSpec struct {
+	// MinReplicas is the minReplicas to override. If RelativeMinReplicas is
+	// also set, this is a lower bound of the relative minReplicas. A ScaleDown
+	// override may leave it unset to lower the minReplicas to 0, which is
+	// raised to 1 unless the manager runs with --enable-scale-to-zero.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+
+	// RelativeMinReplicas is the minReplicas to override relative to the
+	// replicas of each HPA or HorizontalPodAutoscalerX. It is resolved when
+	// each window of the override starts and kept for the rest of the window,
+	// capped at the maxReplicas.
+	// +kubebuilder:validation:Optional
+	RelativeMinReplicas *RelativeMinReplicas `json:"relativeMinReplicas,omitempty"`
+
+	// MaxReplicas is the maxReplicas to override. When several active
+	// overrides set it, the highest maxReplicas is applied.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// Type is whether the override raises or lowers the minReplicas. When
+	// several ScaleDown overrides are active, the highest one applies. The
+	// fallback still applies on top of a ScaleDown override.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ScaleUp
+	Type OverrideType `json:"type,omitempty"`
+
+	// Priority ranks the override against the other active overrides of a
+	// HorizontalPodAutoscalerX with the HighestPriority override strategy.
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Duration is the duration to apply this override. For a recurring
+	// override this is the duration of each occurrence.
 	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration,omitempty"`
+
+	// Time is the time to apply this override. Mutually exclusive with
+	// Schedule.
+	// +kubebuilder:validation:Optional
 	Time metav1.Time `json:"time,omitempty"`
 
+	// Schedule makes the override recur, starting at every time matched by
+	// the schedule. Mutually exclusive with Time.
+	// +kubebuilder:validation:Optional
+	Schedule *Schedule `json:"schedule,omitempty"`
+
+	// LeadTime applies the override this long before each start of the
+	// override, so that the capacity is ready by then rather than only
+	// starting to scale. A warm-up ramps up to the override before the lead
+	// time.
+	// +kubebuilder:validation:Optional
+	LeadTime *LeadTime `json:"leadTime,omitempty"`
+
+	// WarmUp ramps the minReplicas up to the override over the duration
+	// before each start of the override, so that the capacity is ready on
+	// time. The first step is taken when the warm-up starts.
+	// +kubebuilder:validation:Optional
+	WarmUp *Ramp `json:"warmUp,omitempty"`
+
+	// CoolDown ramps the minReplicas back down over the duration after each
+	// end of the override.
+	// +kubebuilder:validation:Optional
+	CoolDown *Ramp `json:"coolDown,omitempty"`
+}
+
+// HPAOverrideSpec defines the desired state of HPAOverride.
+// +kubebuilder:validation:XValidation:rule="has(self.time) != has(self.schedule)",message="exactly one of time or schedule must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.minReplicas) || has(self.relativeMinReplicas) || self.type == 'ScaleDown'",message="at least one of minReplicas or relativeMinReplicas must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.hpaTargetName) != has(self.selector)",message="exactly one of hpaTargetName or selector must be set"
+type HPAOverrideSpec struct {
+	OverrideSpec `json:",inline"`
+
+	// TTLAfterExpiry is how long after the override expires, including any
+	// cool-down, it is deleted. Defaults to the default of the controller
+	// manager, which keeps expired overrides unless it is set.
+	// +kubebuilder:validation:Optional
+	TTLAfterExpiry *metav1.Duration `json:"ttlAfterExpiry,omitempty"`
+
 	// HPATargetName is the name of the HorizontalPodAutoscaler to override.
-	// +kubebuilder:validation:Required
+	// Mutually exclusive with Selector.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
 	HPATargetName string `json:"hpaTargetName,omitempty"`
+
+	// Selector selects the HorizontalPodAutoscalerX objects in the namespace
+	// whose HPAs to override by their labels. Mutually exclusive with
+	// HPATargetName.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Pending;Active;Expired
+type HPAOverridePhase string
+
+const (
+	// HPAOverridePhasePending indicates that the override has not started yet.
+	HPAOverridePhasePending HPAOverridePhase = "Pending"
+	// HPAOverridePhaseActive indicates that the override is currently applied,
+	// including while it warms up or cools down.
+	HPAOverridePhaseActive HPAOverridePhase = "Active"
+	// HPAOverridePhaseExpired indicates that the override has ended.
+	HPAOverridePhaseExpired HPAOverridePhase = "Expired"
+)
+
+// HPAOverrideTarget is a HorizontalPodAutoscalerX affected by the HPAOverride.
+type HPAOverrideTarget struct {
+	// Name is the name of the HorizontalPodAutoscalerX.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Winning indicates whether the HorizontalPodAutoscalerX applies the
+	// minReplicas of this override, i.e. whether it is its status.winner.
+	// +kubebuilder:validation:Optional
+	Winning bool `json:"winning,omitempty"`
 }
 
 // HPAOverrideStatus defines the observed state of HPAOverride.
@@ -30,6 +210,30 @@ type HPAOverrideStatus struct {
 	// Active is the active status of the override.
 	// +kubebuilder:validation:Optional
 	Active bool `json:"active,omitempty"`
+
+	// Phase is the lifecycle phase of the override.
+	// +kubebuilder:validation:Optional
+	Phase HPAOverridePhase `json:"phase,omitempty"`
+
+	// StartTime is the scheduled start of the override. It is applied in
+	// full from the lead time before it, after any warm-up.
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time the override stops being applied in full,
+	// before any cool-down.
+	// +kubebuilder:validation:Optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Targets is the list of HorizontalPodAutoscalerX objects affected by the
+	// override.
+	// +kubebuilder:validation:Optional
+	Targets []HPAOverrideTarget `json:"targets,omitempty"`
+
+	// ObservedGeneration is the generation of the HPAOverride when it was
+	// last observed.
+	// +kubebuilder:validation:Optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -37,7 +241,9 @@ type HPAOverrideStatus struct {
 // +kubebuilder:resource:categories=all,shortName=hpao
 // +kubebuilder:printcolumn:name="HPA",type=string,JSONPath=".spec.hpaTargetName",description="The name of the HorizontalPodAutoscaler to scale"
 // +kubebuilder:printcolumn:name="MinReplicas",type=integer,JSONPath=".spec.minReplicas",description="The minReplicas to override"
+// +kubebuilder:printcolumn:name="MaxReplicas",type=integer,JSONPath=".spec.maxReplicas",description="The maxReplicas to override"
 // +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=".status.active",description="The active status of the override"
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase",description="The lifecycle phase of the override"
 
 // HPAOverride is the Schema for the hpaoverrides API.
 type HPAOverride struct {
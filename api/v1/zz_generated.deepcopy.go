@@ -5,13 +5,158 @@
 package v1
 
 import (
+	"k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHPAOverride) DeepCopyInto(out *ClusterHPAOverride) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHPAOverride.
+func (in *ClusterHPAOverride) DeepCopy() *ClusterHPAOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHPAOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHPAOverride) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHPAOverrideList) DeepCopyInto(out *ClusterHPAOverrideList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterHPAOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHPAOverrideList.
+func (in *ClusterHPAOverrideList) DeepCopy() *ClusterHPAOverrideList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHPAOverrideList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterHPAOverrideList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHPAOverrideSpec) DeepCopyInto(out *ClusterHPAOverrideSpec) {
+	*out = *in
+	in.OverrideSpec.DeepCopyInto(&out.OverrideSpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHPAOverrideSpec.
+func (in *ClusterHPAOverrideSpec) DeepCopy() *ClusterHPAOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHPAOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHPAOverrideStatus) DeepCopyInto(out *ClusterHPAOverrideStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHPAOverrideStatus.
+func (in *ClusterHPAOverrideStatus) DeepCopy() *ClusterHPAOverrideStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHPAOverrideStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fallback) DeepCopyInto(out *Fallback) {
 	*out = *in
 	out.Duration = in.Duration
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]FallbackTrigger, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]FallbackTier, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recovery != nil {
+		in, out := &in.Recovery, &out.Recovery
+		*out = new(FallbackRecovery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fallback.
@@ -24,13 +169,98 @@ func (in *Fallback) DeepCopy() *Fallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackRecovery) DeepCopyInto(out *FallbackRecovery) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.StepDown != nil {
+		in, out := &in.StepDown, &out.StepDown
+		*out = new(FallbackStepDown)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackRecovery.
+func (in *FallbackRecovery) DeepCopy() *FallbackRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackRecoveryStatus) DeepCopyInto(out *FallbackRecoveryStatus) {
+	*out = *in
+	in.HealthySince.DeepCopyInto(&out.HealthySince)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackRecoveryStatus.
+func (in *FallbackRecoveryStatus) DeepCopy() *FallbackRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackStepDown) DeepCopyInto(out *FallbackStepDown) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackStepDown.
+func (in *FallbackStepDown) DeepCopy() *FallbackStepDown {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackStepDown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackTier) DeepCopyInto(out *FallbackTier) {
+	*out = *in
+	out.After = in.After
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackTier.
+func (in *FallbackTier) DeepCopy() *FallbackTier {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FallbackTrigger) DeepCopyInto(out *FallbackTrigger) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackTrigger.
+func (in *FallbackTrigger) DeepCopy() *FallbackTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(FallbackTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPAOverride) DeepCopyInto(out *HPAOverride) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPAOverride.
@@ -86,8 +316,17 @@ func (in *HPAOverrideList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPAOverrideSpec) DeepCopyInto(out *HPAOverrideSpec) {
 	*out = *in
-	out.Duration = in.Duration
-	in.Time.DeepCopyInto(&out.Time)
+	in.OverrideSpec.DeepCopyInto(&out.OverrideSpec)
+	if in.TTLAfterExpiry != nil {
+		in, out := &in.TTLAfterExpiry, &out.TTLAfterExpiry
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPAOverrideSpec.
@@ -103,6 +342,24 @@ func (in *HPAOverrideSpec) DeepCopy() *HPAOverrideSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPAOverrideStatus) DeepCopyInto(out *HPAOverrideStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]HPAOverrideTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPAOverrideStatus.
@@ -115,6 +372,49 @@ func (in *HPAOverrideStatus) DeepCopy() *HPAOverrideStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HPAOverrideTarget) DeepCopyInto(out *HPAOverrideTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPAOverrideTarget.
+func (in *HPAOverrideTarget) DeepCopy() *HPAOverrideTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(HPAOverrideTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HPATemplate) DeepCopyInto(out *HPATemplate) {
+	*out = *in
+	out.ScaleTargetRef = in.ScaleTargetRef
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HPATemplate.
+func (in *HPATemplate) DeepCopy() *HPATemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(HPATemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizontalPodAutoscalerX) DeepCopyInto(out *HorizontalPodAutoscalerX) {
 	*out = *in
@@ -193,9 +493,29 @@ func (in *HorizontalPodAutoscalerXList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizontalPodAutoscalerXSpec) DeepCopyInto(out *HorizontalPodAutoscalerXSpec) {
 	*out = *in
+	if in.HPATemplate != nil {
+		in, out := &in.HPATemplate, &out.HPATemplate
+		*out = new(HPATemplate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Fallback != nil {
 		in, out := &in.Fallback, &out.Fallback
 		*out = new(Fallback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FloorMinReplicas != nil {
+		in, out := &in.FloorMinReplicas, &out.FloorMinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReleaseMinReplicas != nil {
+		in, out := &in.ReleaseMinReplicas, &out.ReleaseMinReplicas
+		*out = new(int32)
 		**out = **in
 	}
 }
@@ -220,11 +540,81 @@ func (in *HorizontalPodAutoscalerXStatus) DeepCopyInto(out *HorizontalPodAutosca
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureSince != nil {
+		in, out := &in.FailureSince, &out.FailureSince
+		*out = (*in).DeepCopy()
+	}
+	if in.FallbackEngagedAt != nil {
+		in, out := &in.FallbackEngagedAt, &out.FallbackEngagedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FallbackTrigger != nil {
+		in, out := &in.FallbackTrigger, &out.FallbackTrigger
+		*out = new(FallbackTrigger)
+		**out = **in
+	}
+	if in.FallbackTier != nil {
+		in, out := &in.FallbackTier, &out.FallbackTier
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextFallbackTierTime != nil {
+		in, out := &in.NextFallbackTierTime, &out.NextFallbackTierTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FallbackRecovery != nil {
+		in, out := &in.FallbackRecovery, &out.FallbackRecovery
+		*out = new(FallbackRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResolvedOverrides != nil {
+		in, out := &in.ResolvedOverrides, &out.ResolvedOverrides
+		*out = make([]ResolvedOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OverrideReadiness != nil {
+		in, out := &in.OverrideReadiness, &out.OverrideReadiness
+		*out = make([]OverrideReadiness, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartupLatency != nil {
+		in, out := &in.StartupLatency, &out.StartupLatency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ObservedGeneration != nil {
 		in, out := &in.ObservedGeneration, &out.ObservedGeneration
 		*out = new(int64)
 		**out = **in
 	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Candidates != nil {
+		in, out := &in.Candidates, &out.Candidates
+		*out = make([]MinReplicasCandidate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Winner != nil {
+		in, out := &in.Winner, &out.Winner
+		*out = new(MinReplicasCandidate)
+		**out = **in
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerXStatus.
@@ -236,3 +626,164 @@ func (in *HorizontalPodAutoscalerXStatus) DeepCopy() *HorizontalPodAutoscalerXSt
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeadTime) DeepCopyInto(out *LeadTime) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeadTime.
+func (in *LeadTime) DeepCopy() *LeadTime {
+	if in == nil {
+		return nil
+	}
+	out := new(LeadTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MinReplicasCandidate) DeepCopyInto(out *MinReplicasCandidate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinReplicasCandidate.
+func (in *MinReplicasCandidate) DeepCopy() *MinReplicasCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(MinReplicasCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideReadiness) DeepCopyInto(out *OverrideReadiness) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideReadiness.
+func (in *OverrideReadiness) DeepCopy() *OverrideReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(OverrideReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
+	*out = *in
+	if in.RelativeMinReplicas != nil {
+		in, out := &in.RelativeMinReplicas, &out.RelativeMinReplicas
+		*out = new(RelativeMinReplicas)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	out.Duration = in.Duration
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(Schedule)
+		**out = **in
+	}
+	if in.LeadTime != nil {
+		in, out := &in.LeadTime, &out.LeadTime
+		*out = new(LeadTime)
+		**out = **in
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(Ramp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoolDown != nil {
+		in, out := &in.CoolDown, &out.CoolDown
+		*out = new(Ramp)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideSpec.
+func (in *OverrideSpec) DeepCopy() *OverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ramp) DeepCopyInto(out *Ramp) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ramp.
+func (in *Ramp) DeepCopy() *Ramp {
+	if in == nil {
+		return nil
+	}
+	out := new(Ramp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelativeMinReplicas) DeepCopyInto(out *RelativeMinReplicas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelativeMinReplicas.
+func (in *RelativeMinReplicas) DeepCopy() *RelativeMinReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(RelativeMinReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedOverride) DeepCopyInto(out *ResolvedOverride) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedOverride.
+func (in *ResolvedOverride) DeepCopy() *ResolvedOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
+func (in *Schedule) DeepCopy() *Schedule {
+	if in == nil {
+		return nil
+	}
+	out := new(Schedule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
 	"path/filepath"
+	"time"
+
+	// Embed the IANA time zone database so that HPAOverride schedules can be
+	// evaluated in any time zone regardless of the base image.
+	_ "time/tzdata"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -23,6 +29,7 @@ import (
 
 	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
 	"rrethy.io/horizontalpodautoscalerx/internal/controller"
+	webhookautoscalingxv1 "rrethy.io/horizontalpodautoscalerx/internal/webhook/v1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -47,6 +54,10 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var dryRun bool
+	var scaleToZero bool
+	var maxStartupLatency time.Duration
+	var overrideTTLAfterExpiry time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -65,6 +76,17 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, every HorizontalPodAutoscalerX runs in DryRun mode regardless of its spec.mode, so no HPA is updated.")
+	flag.DurationVar(&overrideTTLAfterExpiry, "override-ttl-after-expiry", 0,
+		"How long after they expire to delete the HPAOverrides that don't set a spec.ttlAfterExpiry. "+
+			"Expired HPAOverrides are kept if 0.")
+	flag.BoolVar(&scaleToZero, "enable-scale-to-zero", false,
+		"If set, the minReplicas of an HPA may be lowered to 0, which requires the HPAScaleToZero feature gate and "+
+			"an Object or External metric. Otherwise it is raised to 1.")
+	flag.DurationVar(&maxStartupLatency, "max-startup-latency", 10*time.Minute,
+		"The longest startup latency observed for the pods of a scale target. Pods that took longer to become ready "+
+			"are ignored.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -176,14 +198,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controller.SetupFieldIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
 	if err = (&controller.HorizontalPodAutoscalerXReconciler{
-		Client:        mgr.GetClient(),
-		EventRecorder: mgr.GetEventRecorderFor(controller.ControllerName),
-		Scheme:        mgr.GetScheme(),
+		Client:            mgr.GetClient(),
+		EventRecorder:     mgr.GetEventRecorderFor(controller.ControllerName),
+		Scheme:            mgr.GetScheme(),
+		DryRun:            dryRun,
+		ScaleToZero:       scaleToZero,
+		MaxStartupLatency: maxStartupLatency,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "HorizontalPodAutoscalerX")
 		os.Exit(1)
 	}
+	if err = (&controller.HPAOverrideReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		EventRecorder:         mgr.GetEventRecorderFor(controller.HPAOverrideControllerName),
+		DefaultTTLAfterExpiry: overrideTTLAfterExpiry,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "HPAOverride")
+		os.Exit(1)
+	}
+	if err = (&controller.ClusterHPAOverrideReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterHPAOverride")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookautoscalingxv1.SetupHorizontalPodAutoscalerXWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HorizontalPodAutoscalerX")
+			os.Exit(1)
+		}
+		if err = webhookautoscalingxv1.SetupHPAOverrideWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "HPAOverride")
+			os.Exit(1)
+		}
+		if err = webhookautoscalingxv1.SetupClusterHPAOverrideWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterHPAOverride")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+	custompredicate "rrethy.io/horizontalpodautoscalerx/internal/predicate"
+)
+
+const (
+	ClusterHPAOverrideControllerName = "clusterhpaoverride"
+)
+
+// ClusterHPAOverrideReconciler reconciles the status of a ClusterHPAOverride object
+type ClusterHPAOverrideReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Clock  clock.Clock
+}
+
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=clusterhpaoverrides,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=clusterhpaoverrides/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=horizontalpodautoscalerxes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Reconcile keeps the ClusterHPAOverride status in sync with its lifecycle.
+func (r *ClusterHPAOverrideReconciler) Reconcile(ctx context.Context, clusterHPAOverride *autoscalingxv1.ClusterHPAOverride) (res ctrl.Result, retErr error) {
+	if !clusterHPAOverride.DeletionTimestamp.IsZero() {
+		// The object is being deleted, don't do anything.
+		return ctrl.Result{}, nil
+	}
+
+	log := log.FromContext(ctx)
+	orig := clusterHPAOverride.DeepCopy()
+	defer func() {
+		if !apiequality.Semantic.DeepEqual(orig, clusterHPAOverride) {
+			if err := r.Status().Patch(ctx, clusterHPAOverride, client.MergeFrom(orig)); err != nil {
+				log.Error(err, "updating status")
+			}
+		}
+	}()
+
+	hpaxs, err := listHPAXForClusterHPAOverride(ctx, r, clusterHPAOverride)
+	if err != nil {
+		log.Error(err, "listing targets")
+		return ctrl.Result{}, err
+	}
+
+	now := r.Clock.Now()
+	hpaOverride := clusterHPAOverride.AsHPAOverride()
+	led := leadOverride(&hpaOverride, hpaxs)
+	phase, nextTransition, err := overridePhase(&led, now)
+	if err != nil {
+		log.Error(err, "evaluating override window")
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+	start, end, _ := overrideWindow(&hpaOverride, now)
+	clusterHPAOverride.Status.Phase = phase
+	clusterHPAOverride.Status.Active = phase == autoscalingxv1.HPAOverridePhaseActive
+	clusterHPAOverride.Status.StartTime = nil
+	clusterHPAOverride.Status.EndTime = nil
+	if !start.IsZero() {
+		clusterHPAOverride.Status.StartTime = &metav1.Time{Time: start}
+		clusterHPAOverride.Status.EndTime = &metav1.Time{Time: end}
+	}
+	clusterHPAOverride.Status.ObservedGeneration = ptr.To(clusterHPAOverride.Generation)
+
+	if !nextTransition.IsZero() {
+		res.RequeueAfter = nextTransition.Sub(now)
+	}
+	return res, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterHPAOverrideReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(ClusterHPAOverrideControllerName).
+		For(
+			&autoscalingxv1.ClusterHPAOverride{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&autoscalingxv1.HorizontalPodAutoscalerX{},
+			handler.EnqueueRequestsFromMapFunc(r.findClusterHPAOverridesForHPAX),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, custompredicate.HPAXOverrideStatusChangedPredicate{})),
+		).
+		Complete(reconcile.AsReconciler(mgr.GetClient(), r))
+}
+
+// findClusterHPAOverridesForHPAX finds all ClusterHPAOverride objects that select the given HorizontalPodAutoscalerX.
+func (r *ClusterHPAOverrideReconciler) findClusterHPAOverridesForHPAX(ctx context.Context, o client.Object) []reconcile.Request {
+	hpax, ok := o.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return nil
+	}
+
+	clusterHPAOverrides, err := listClusterHPAOverridesForHPAX(ctx, r, hpax)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(clusterHPAOverrides))
+	for _, clusterHPAOverride := range clusterHPAOverrides {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: clusterHPAOverride.GetName()},
+		})
+	}
+	return requests
+}
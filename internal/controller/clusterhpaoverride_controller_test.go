@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+var _ = Describe("ClusterHPAOverride Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		AfterEach(func() {
+			By("deleting any ClusterHPAOverride")
+			Expect(k8sClient.DeleteAllOf(ctx, &autoscalingxv1.ClusterHPAOverride{})).To(Succeed())
+		})
+
+		It("should mark an override that is active as active", func() {
+			By("creating a cluster override that is active")
+			start := fakeclock.Now().Add(-1 * time.Hour).Truncate(time.Second)
+			clusterHPAOverride := &autoscalingxv1.ClusterHPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-override"},
+				Spec: autoscalingxv1.ClusterHPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: start},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterHPAOverride)).To(Succeed())
+
+			By("getting the cluster override to check its status")
+			Eventually(func(g Gomega) {
+				clusterHPAOverride := &autoscalingxv1.ClusterHPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-cluster-override"}, clusterHPAOverride)).To(Succeed())
+				g.Expect(clusterHPAOverride.Status.Phase).To(Equal(autoscalingxv1.HPAOverridePhaseActive))
+				g.Expect(clusterHPAOverride.Status.Active).To(BeTrue())
+				g.Expect(clusterHPAOverride.Status.StartTime).NotTo(BeNil())
+				g.Expect(clusterHPAOverride.Status.StartTime.Time).To(BeTemporally("==", start))
+				g.Expect(clusterHPAOverride.Status.EndTime).NotTo(BeNil())
+				g.Expect(clusterHPAOverride.Status.EndTime.Time).To(BeTemporally("==", start.Add(2*time.Hour)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+	})
+})
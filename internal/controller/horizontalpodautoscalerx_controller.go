@@ -2,22 +2,30 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	autoscalingv2apply "k8s.io/client-go/applyconfigurations/autoscaling/v2"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -29,6 +37,28 @@ import (
 
 const (
 	ControllerName = "horizontalpodautoscalerx"
+
+	// FieldManager is the field manager that server-side applies the replicas of the HPA.
+	FieldManager = "horizontalpodautoscalerx"
+
+	// TemplateFieldManager is the field manager that server-side applies the hpaTemplate to the HPA. It is separate from
+	// the FieldManager so that neither apply removes the fields of the other.
+	TemplateFieldManager = "horizontalpodautoscalerx-template"
+
+	// Finalizer is the finalizer added to HorizontalPodAutoscalerX objects so that the HPA can be
+	// released back to its original minReplicas when they are deleted.
+	Finalizer = "autoscalingx.rrethy.io/finalizer"
+
+	// OriginalMinReplicasAnnotation is the annotation on the HPA that records its minReplicas from
+	// before it was first updated by a HorizontalPodAutoscalerX.
+	OriginalMinReplicasAnnotation = "autoscalingx.rrethy.io/original-min-replicas"
+
+	// OriginalMaxReplicasAnnotation is the annotation on the HPA that records its maxReplicas from
+	// before it was first updated by a HorizontalPodAutoscalerX.
+	OriginalMaxReplicasAnnotation = "autoscalingx.rrethy.io/original-max-replicas"
+
+	// defaultMaxStartupLatency is the MaxStartupLatency of a reconciler that doesn't set one.
+	defaultMaxStartupLatency = 10 * time.Minute
 )
 
 // HorizontalPodAutoscalerXReconciler reconciles a HorizontalPodAutoscalerX object
@@ -37,6 +67,21 @@ type HorizontalPodAutoscalerXReconciler struct {
 	Scheme        *runtime.Scheme
 	EventRecorder record.EventRecorder
 	Clock         clock.Clock
+
+	// DryRun runs every HorizontalPodAutoscalerX in DryRun mode regardless of its spec.mode.
+	DryRun bool
+
+	// ScaleToZero lets the minReplicas of the HPA be 0, which the HPA only accepts where the HPAScaleToZero feature
+	// gate is enabled. Otherwise it is raised to 1.
+	ScaleToZero bool
+
+	// MaxStartupLatency is the longest startup latency observed for the pods of a scale target. It defaults to
+	// defaultMaxStartupLatency.
+	MaxStartupLatency time.Duration
+
+	// APIReader reads the pods of the scale targets from the API server, so that they aren't all cached. It defaults to
+	// the API reader of the manager.
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=horizontalpodautoscalerxes,verbs=get;list;watch;create;update;patch;delete
@@ -44,9 +89,13 @@ type HorizontalPodAutoscalerXReconciler struct {
 // +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=horizontalpodautoscalerxes/finalizers,verbs=update
 // +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=hpaoverrides,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=hpaoverrides/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=clusterhpaoverrides,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;replicasets,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -55,19 +104,31 @@ type HorizontalPodAutoscalerXReconciler struct {
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.18.2/pkg/reconcile
 // This Reconcile method uses the ObjectReconciler interface from https://github.com/kubernetes-sigs/controller-runtime/pull/2592.
 func (r *HorizontalPodAutoscalerXReconciler) Reconcile(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) (res ctrl.Result, retErr error) {
+	log := log.FromContext(ctx)
 	if !hpax.DeletionTimestamp.IsZero() {
-		// The object is being deleted, don't do anything.
+		if err := r.finalize(ctx, hpax); err != nil {
+			log.Error(err, "finalizing")
+			r.EventRecorder.Event(hpax, corev1.EventTypeWarning, "FailedToReleaseHPA", err.Error())
+			return ctrl.Result{}, fmt.Errorf("finalizing: %w", err)
+		}
 		return ctrl.Result{}, nil
 	}
 
-	log := log.FromContext(ctx)
+	if controllerutil.AddFinalizer(hpax, Finalizer) {
+		if err := r.Update(ctx, hpax); err != nil {
+			return ctrl.Result{}, fmt.Errorf("adding finalizer: %w", err)
+		}
+	}
+
 	orig := hpax.DeepCopy()
 	defer func() {
-		// we don't even need to do this really, we're always updating the status
-		if !apiequality.Semantic.DeepEqual(orig, hpax) {
-			if err := r.Status().Update(ctx, hpax); err != nil {
-				log.Error(err, "updating status")
-			}
+		if apiequality.Semantic.DeepEqual(orig.Status, hpax.Status) {
+			writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "status", "skipped").Inc()
+			return
+		}
+		writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "status", "performed").Inc()
+		if err := r.Status().Patch(ctx, hpax, client.MergeFrom(orig)); err != nil {
+			log.Error(err, "updating status")
 		}
 	}()
 
@@ -78,16 +139,26 @@ func (r *HorizontalPodAutoscalerXReconciler) Reconcile(ctx context.Context, hpax
 		return ctrl.Result{}, fmt.Errorf("getting HPA: %w", err)
 	}
 
-	err = r.updateHpaMinReplicas(ctx, hpax, hpa)
+	nextTransition, err := r.updateHpaReplicas(ctx, hpax, hpa)
 	if err != nil {
-		log.Error(err, "updating HPA spec.minReplicas")
+		log.Error(err, "updating HPA replicas")
 		r.EventRecorder.Event(hpax, corev1.EventTypeWarning, "FailedToUpdateHPA", err.Error())
-		return ctrl.Result{}, fmt.Errorf("updating HPA spec.minReplicas: %w", err)
+		return ctrl.Result{}, fmt.Errorf("updating HPA replicas: %w", err)
 	}
 
 	hpax.Status.ObservedGeneration = ptr.To(hpax.Generation)
-	r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionTrue, "HPAUpdated", "updated the minReplicas of the hpa")
-	return ctrl.Result{}, nil
+	if r.isDryRun(hpax) {
+		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionTrue, "DryRun", "computed the minReplicas and maxReplicas of the hpa without updating it")
+	} else {
+		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionTrue, "HPAUpdated", "updated the minReplicas and maxReplicas of the hpa")
+	}
+
+	// Overrides starting or expiring and fallbacks kicking in are driven by
+	// the clock rather than by watch events, so requeue for the next one.
+	if !nextTransition.IsZero() {
+		res.RequeueAfter = nextTransition.Sub(r.Clock.Now())
+	}
+	return res, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -95,40 +166,29 @@ func (r *HorizontalPodAutoscalerXReconciler) SetupWithManager(mgr ctrl.Manager)
 	if r.Clock == nil {
 		r.Clock = clock.RealClock{}
 	}
-
-	err := mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&autoscalingxv1.HorizontalPodAutoscalerX{},
-		"spec.hpaTargetName",
-		func(obj client.Object) []string {
-			return []string{obj.(*autoscalingxv1.HorizontalPodAutoscalerX).Spec.HPATargetName}
-		})
-	if err != nil {
-		return err
+	if r.MaxStartupLatency == 0 {
+		r.MaxStartupLatency = defaultMaxStartupLatency
 	}
-
-	err = mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&autoscalingxv1.HPAOverride{},
-		"spec.hpaTargetName",
-		func(obj client.Object) []string {
-			return []string{obj.(*autoscalingxv1.HPAOverride).Spec.HPATargetName}
-		})
-	if err != nil {
-		return err
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(
 			&autoscalingxv1.HorizontalPodAutoscalerX{},
-			builder.WithPredicates(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+				// The labels decide which HPAOverride objects select the HorizontalPodAutoscalerX.
+				predicate.LabelChangedPredicate{},
+			)),
 		).
 		Watches(
 			&autoscalingv2.HorizontalPodAutoscaler{},
 			handler.EnqueueRequestsFromMapFunc(r.findHPAXForHPA),
 			builder.WithPredicates(predicate.Or(
-				custompredicate.HPAScalingActiveChangedPredicate{},
+				custompredicate.HPAConditionsChangedPredicate{},
 				custompredicate.HPAMinReplicasChangedPredicate{},
 			)),
 		).
@@ -137,9 +197,26 @@ func (r *HorizontalPodAutoscalerXReconciler) SetupWithManager(mgr ctrl.Manager)
 			handler.EnqueueRequestsFromMapFunc(r.findHPAXForHPAOverride),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		).
+		Watches(
+			&autoscalingxv1.ClusterHPAOverride{},
+			handler.EnqueueRequestsFromMapFunc(r.findHPAXForClusterHPAOverride),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findHPAXForNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}),
+		).
 		Complete(reconcile.AsReconciler(mgr.GetClient(), r))
 }
 
+// removeCondition removes the condition of the given type from the HorizontalPodAutoscalerX.
+func removeCondition(hpax *autoscalingxv1.HorizontalPodAutoscalerX, conditionType autoscalingxv1.HorizontalPodAutoscalerXConditionType) {
+	hpax.Status.Conditions = slices.DeleteFunc(hpax.Status.Conditions, func(cond autoscalingxv1.HorizontalPodAutoscalerXCondition) bool {
+		return cond.Type == conditionType
+	})
+}
+
 // setCondition sets the condition of the HorizontalPodAutoscalerX.
 func (r *HorizontalPodAutoscalerXReconciler) setCondition(
 	hpax *autoscalingxv1.HorizontalPodAutoscalerX,
@@ -177,6 +254,16 @@ func (r *HorizontalPodAutoscalerXReconciler) setCondition(
 	})
 }
 
+// getCondition returns the condition of the given type of the HorizontalPodAutoscalerX, or nil if it isn't set.
+func getCondition(hpax *autoscalingxv1.HorizontalPodAutoscalerX, conditionType autoscalingxv1.HorizontalPodAutoscalerXConditionType) *autoscalingxv1.HorizontalPodAutoscalerXCondition {
+	for i := range hpax.Status.Conditions {
+		if hpax.Status.Conditions[i].Type == conditionType {
+			return &hpax.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 // findHPAXForHPA finds all HorizontalPodAutoscalerX objects that target the given HPA.
 func (r *HorizontalPodAutoscalerXReconciler) findHPAXForHPA(ctx context.Context, o client.Object) []reconcile.Request {
 	hpa, ok := o.(*autoscalingv2.HorizontalPodAutoscaler)
@@ -187,7 +274,7 @@ func (r *HorizontalPodAutoscalerXReconciler) findHPAXForHPA(ctx context.Context,
 	hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
 	if err := r.List(ctx, hpaxList, &client.ListOptions{
 		Namespace:     hpa.GetNamespace(),
-		FieldSelector: fields.OneTermEqualSelector("spec.hpaTargetName", hpa.GetName()),
+		FieldSelector: fields.OneTermEqualSelector(hpaTargetNameField, hpa.GetName()),
 	}); err != nil {
 		return nil
 	}
@@ -204,18 +291,57 @@ func (r *HorizontalPodAutoscalerXReconciler) findHPAXForHPA(ctx context.Context,
 	return requests
 }
 
-// findHPAXForHPAOverride finds all HorizontalPodAutoscalerX objects that target the given HPAOverride.
+// findHPAXForHPAOverride finds all HorizontalPodAutoscalerX objects targeted by the given HPAOverride.
 func (r *HorizontalPodAutoscalerXReconciler) findHPAXForHPAOverride(ctx context.Context, o client.Object) []reconcile.Request {
 	hpaOverride, ok := o.(*autoscalingxv1.HPAOverride)
 	if !ok {
 		return nil
 	}
 
+	hpaxs, err := listHPAXForHPAOverride(ctx, r, hpaOverride)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(hpaxs))
+	for _, hpax := range hpaxs {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      hpax.GetName(),
+				Namespace: hpax.GetNamespace(),
+			},
+		})
+	}
+	return requests
+}
+
+// findHPAXForClusterHPAOverride finds all HorizontalPodAutoscalerX objects selected by the given ClusterHPAOverride.
+func (r *HorizontalPodAutoscalerXReconciler) findHPAXForClusterHPAOverride(ctx context.Context, o client.Object) []reconcile.Request {
+	clusterHPAOverride, ok := o.(*autoscalingxv1.ClusterHPAOverride)
+	if !ok {
+		return nil
+	}
+
+	hpaxs, err := listHPAXForClusterHPAOverride(ctx, r, clusterHPAOverride)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(hpaxs))
+	for _, hpax := range hpaxs {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      hpax.GetName(),
+				Namespace: hpax.GetNamespace(),
+			},
+		})
+	}
+	return requests
+}
+
+// findHPAXForNamespace finds all HorizontalPodAutoscalerX objects in the given namespace, since its labels decide
+// which ClusterHPAOverride objects select them.
+func (r *HorizontalPodAutoscalerXReconciler) findHPAXForNamespace(ctx context.Context, o client.Object) []reconcile.Request {
 	hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
-	if err := r.List(ctx, hpaxList, &client.ListOptions{
-		Namespace:     hpaOverride.GetNamespace(),
-		FieldSelector: fields.OneTermEqualSelector("spec.hpaTargetName", hpaOverride.Spec.HPATargetName),
-	}); err != nil {
+	if err := r.List(ctx, hpaxList, client.InNamespace(o.GetName())); err != nil {
 		return nil
 	}
 	requests := make([]reconcile.Request, 0, len(hpaxList.Items))
@@ -230,8 +356,13 @@ func (r *HorizontalPodAutoscalerXReconciler) findHPAXForHPAOverride(ctx context.
 	return requests
 }
 
-// getHPA retrieves the HorizontalPodAutoscaler object associated with the given HorizontalPodAutoscalerX.
+// getHPA retrieves the HorizontalPodAutoscaler object associated with the given HorizontalPodAutoscalerX, creating it
+// from the hpaTemplate if set.
 func (r *HorizontalPodAutoscalerXReconciler) getHPA(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if hpax.Spec.HPATemplate != nil {
+		return r.applyHPATemplate(ctx, hpax)
+	}
+
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
 	err := r.Get(ctx, client.ObjectKey{Name: hpax.Spec.HPATargetName, Namespace: hpax.Namespace}, hpa)
 	if err != nil {
@@ -241,71 +372,959 @@ func (r *HorizontalPodAutoscalerXReconciler) getHPA(ctx context.Context, hpax *a
 	return hpa, nil
 }
 
-// getFallbackSuggestion calculates the desired minReplicas for the HorizontalPodAutoscalerX based on the ScalingActive condition for the hpa.
-func (r *HorizontalPodAutoscalerXReconciler) getFallbackSuggestion(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) int32 {
-	var cond *autoscalingv2.HorizontalPodAutoscalerCondition
-	for _, condition := range hpa.Status.Conditions {
-		if condition.Type == autoscalingv2.ScalingActive {
-			cond = &condition
+// applyHPATemplate creates the HPA from the hpaTemplate of the HorizontalPodAutoscalerX, or updates it to match the
+// hpaTemplate, and makes the HorizontalPodAutoscalerX its controller. It server-side applies only the fields set in the
+// hpaTemplate, so the fields the API server defaults, e.g. the metrics or the behavior policies, don't make it update
+// the HPA on every reconcile, and it is skipped if the HPA already matches the hpaTemplate. The minReplicas and
+// maxReplicas are left to updateHpaReplicas once the HPA exists. In DryRun mode the HPA isn't written, and if it
+// doesn't exist the HPA the hpaTemplate would create is returned instead.
+func (r *HorizontalPodAutoscalerXReconciler) applyHPATemplate(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Get(ctx, client.ObjectKey{Name: hpax.Spec.HPATargetName, Namespace: hpax.Namespace}, hpa)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("getting HPA: %w", err)
+	}
+	created := apierrors.IsNotFound(err)
+
+	// The maxReplicas is required, so it is only left out once another field manager owns it.
+	patch, err := r.hpaTemplateApply(hpax, !ownedByOthers(hpa, TemplateFieldManager, "f:spec", "f:maxReplicas"))
+	if err == nil && r.isDryRun(hpax) {
+		if created {
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(patch.Object, hpa)
 		}
+		return hpa, err
+	}
+	if err == nil && !created && r.hpaMatchesTemplate(hpax, hpa) {
+		writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "hpa_template", "skipped").Inc()
+		return hpa, nil
+	}
+	if err == nil {
+		writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "hpa_template", "performed").Inc()
+		err = r.Patch(ctx, patch, client.Apply, client.FieldOwner(TemplateFieldManager), client.ForceOwnership)
+	}
+	if err == nil {
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(patch.Object, hpa)
+	}
+	if err != nil {
+		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionFalse, "FailedToApplyHPATemplate", "failed creating or updating the target hpa from the hpaTemplate")
+		return nil, err
+	}
+	if created {
+		r.EventRecorder.Eventf(hpax, corev1.EventTypeNormal, "CreatedHPA", "created the hpa %s from the hpaTemplate", hpa.Name)
 	}
+	return hpa, nil
+}
 
-	if hpax.Spec.Fallback == nil ||
-		cond == nil ||
-		cond.Status == corev1.ConditionTrue ||
-		cond.Status == corev1.ConditionUnknown {
-		r.setCondition(hpax, autoscalingxv1.ConditionFallback, corev1.ConditionFalse, "ScalingActive", "scaling active condition is not false")
-		return hpax.Spec.MinReplicas
+// hpaTemplateApply returns the server-side apply configuration with only the fields of the HPA the TemplateFieldManager
+// owns: its scaleTargetRef, the metrics and behavior set in the hpaTemplate, its controller reference, and its
+// maxReplicas if withMaxReplicas is set.
+func (r *HorizontalPodAutoscalerXReconciler) hpaTemplateApply(hpax *autoscalingxv1.HorizontalPodAutoscalerX, withMaxReplicas bool) (*unstructured.Unstructured, error) {
+	template := hpax.Spec.HPATemplate
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta:   metav1.TypeMeta{APIVersion: autoscalingv2.SchemeGroupVersion.String(), Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: metav1.ObjectMeta{Name: hpax.Spec.HPATargetName, Namespace: hpax.Namespace},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: template.ScaleTargetRef,
+			Metrics:        template.Metrics,
+			Behavior:       template.Behavior,
+			MaxReplicas:    template.MaxReplicas,
+		},
+	}
+	if err := controllerutil.SetControllerReference(hpax, hpa, r.Scheme); err != nil {
+		return nil, err
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hpa)
+	if err != nil {
+		return nil, fmt.Errorf("converting the hpa template: %w", err)
 	}
+	// Leave out the fields the typed HPA always serializes but the template doesn't set.
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	if !withMaxReplicas {
+		unstructured.RemoveNestedField(obj, "spec", "maxReplicas")
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
 
-	if cond.LastTransitionTime.Time.Add(hpax.Spec.Fallback.Duration.Duration).After(r.Clock.Now()) {
-		r.setCondition(hpax, autoscalingxv1.ConditionFallback, corev1.ConditionTrue, "ScalingRecentlyInactive", "scaling active condition is false for not long enough")
-		return hpax.Spec.MinReplicas
+// hpaTemplateAction returns what applying the hpaTemplate of the HorizontalPodAutoscalerX would do to the hpa: Create
+// if the hpa doesn't exist yet, Update if it doesn't match the hpaTemplate, or nothing.
+func (r *HorizontalPodAutoscalerXReconciler) hpaTemplateAction(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) string {
+	switch {
+	case hpax.Spec.HPATemplate == nil:
+		return ""
+	case hpa.UID == "":
+		return "Create"
+	case !r.hpaMatchesTemplate(hpax, hpa):
+		return "Update"
+	default:
+		return ""
 	}
+}
 
-	r.setCondition(hpax, autoscalingxv1.ConditionFallback, corev1.ConditionFalse, "ScalingInactive", "scaling active condition is false for long enough")
-	return hpax.Spec.Fallback.MinReplicas
+// hpaMatchesTemplate returns whether the HorizontalPodAutoscalerX controls the hpa and the spec of the hpa has every
+// field that applying the hpaTemplate would set. The fields the API server defaults are ignored. An hpaTemplate that
+// can't be converted never matches, so that applying it reports the error.
+func (r *HorizontalPodAutoscalerXReconciler) hpaMatchesTemplate(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) bool {
+	if !metav1.IsControlledBy(hpa, hpax) {
+		return false
+	}
+	patch, err := r.hpaTemplateApply(hpax, !ownedByOthers(hpa, TemplateFieldManager, "f:spec", "f:maxReplicas"))
+	if err != nil {
+		return false
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hpa)
+	if err != nil {
+		return false
+	}
+	return containsFields(obj["spec"], patch.Object["spec"])
 }
 
-func (r *HorizontalPodAutoscalerXReconciler) getOverrideSuggestion(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) int32 {
-	hpaOverrideList := &autoscalingxv1.HPAOverrideList{}
-	if err := r.List(ctx, hpaOverrideList, &client.ListOptions{
-		Namespace:     hpax.Namespace,
-		FieldSelector: fields.OneTermEqualSelector("spec.hpaTargetName", hpax.Spec.HPATargetName),
-	}); err != nil {
-		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionFalse, "FailedToGetHPAOverride", "failed getting target hpa overrides")
-		return hpax.Spec.MinReplicas
+// containsFields returns whether the unstructured value has every field of the desired value with the same value.
+// Lists have to be of the same length, with every item containing the fields of the desired item.
+func containsFields(value, desired any) bool {
+	switch desired := desired.(type) {
+	case map[string]any:
+		value, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, desiredField := range desired {
+			if !containsFields(value[key], desiredField) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		value, ok := value.([]any)
+		if !ok || len(value) != len(desired) {
+			return false
+		}
+		for i := range desired {
+			if !containsFields(value[i], desired[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return apiequality.Semantic.DeepEqual(value, desired)
 	}
+}
 
-	replicas := []int32{}
+// getFallbackSuggestion calculates the desired minReplicas for the HorizontalPodAutoscalerX based on the fallback triggers
+// matching the conditions of the hpa. It also returns the time at which the suggestion will next change on its own, or
+// the zero time if it won't.
+func (r *HorizontalPodAutoscalerXReconciler) getFallbackSuggestion(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) (int32, time.Time) {
+	cond := getHPACondition(hpa, autoscalingv2.ScalingActive)
 	now := r.Clock.Now()
-	for _, hpaOverride := range hpaOverrideList.Items {
-		if hpaOverride.Spec.Time.After(now) {
+	// A timestamp rather than a duration, so that it doesn't freeze between reconciles.
+	scalingInactiveSince := 0.0
+	if cond != nil && cond.Status == corev1.ConditionFalse {
+		scalingInactiveSince = float64(cond.LastTransitionTime.Unix())
+	}
+	scalingInactiveSinceGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(scalingInactiveSince)
+
+	previousTier := hpax.Status.FallbackTier
+	hpax.Status.FallbackTrigger = nil
+	hpax.Status.FallbackTier = nil
+	hpax.Status.NextFallbackTierTime = nil
+	hpax.Status.FailureSince = nil
+	removeCondition(hpax, autoscalingxv1.ConditionTypeFallbackTriggered) //nolint:staticcheck // removing the deprecated condition
+
+	var triggers []autoscalingxv1.FallbackTrigger
+	if hpax.Spec.Fallback != nil {
+		triggers = fallbackTriggers(hpax.Spec.Fallback)
+	}
+
+	var deadline time.Time
+	var fired *autoscalingxv1.FallbackTrigger
+	var firedCond *autoscalingv2.HorizontalPodAutoscalerCondition
+	for i, trigger := range triggers {
+		cond := getHPACondition(hpa, trigger.Type)
+		if cond == nil || cond.Status != trigger.Status || (trigger.Reason != "" && cond.Reason != trigger.Reason) {
+			continue
+		}
+
+		if hpax.Status.FailureSince == nil || cond.LastTransitionTime.Before(hpax.Status.FailureSince) {
+			hpax.Status.FailureSince = cond.LastTransitionTime.DeepCopy()
+		}
+		triggerDeadline := cond.LastTransitionTime.Add(trigger.Duration.Duration)
+		if triggerDeadline.After(now) {
+			deadline = earliest(deadline, triggerDeadline)
+			continue
+		}
+		if fired == nil {
+			fired, firedCond = &triggers[i], cond
+		}
+	}
+
+	if fired != nil {
+		if hpax.Status.FallbackEngagedAt == nil {
+			hpax.Status.FallbackEngagedAt = &metav1.Time{Time: now}
+			fallbackActivationsCounter.WithLabelValues(hpax.Namespace, hpax.Name).Inc()
+		}
+		minReplicas, tier, nextTier := fallbackTier(hpax.Spec.Fallback, now.Sub(firedCond.LastTransitionTime.Time))
+		var nextTierTime time.Time
+		if nextTier > 0 {
+			nextTierTime = firedCond.LastTransitionTime.Add(nextTier)
+			hpax.Status.NextFallbackTierTime = &metav1.Time{Time: nextTierTime}
+		}
+		hpax.Status.FallbackTrigger = fired.DeepCopy()
+		hpax.Status.FallbackTier = ptr.To(tier)
+		hpax.Status.FallbackRecovery = nil
+		r.setCondition(hpax, autoscalingxv1.ConditionFallbackPending, corev1.ConditionFalse, "FallbackActive", "the fallback is applied")
+		r.setCondition(hpax, autoscalingxv1.ConditionFallbackActive, corev1.ConditionTrue, "TriggerFired",
+			fmt.Sprintf("fallback trigger %s matches the hpa conditions for long enough", describeFallbackTrigger(*fired)))
+		fallbackEngagedGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(1)
+		return minReplicas, nextTierTime
+	}
+
+	if !deadline.IsZero() {
+		r.setCondition(hpax, autoscalingxv1.ConditionFallbackPending, corev1.ConditionTrue, "TriggerMatched", "a fallback trigger matches the hpa conditions for not long enough")
+	} else {
+		r.setCondition(hpax, autoscalingxv1.ConditionFallbackPending, corev1.ConditionFalse, "NoTriggerMatched", "no fallback trigger matches the hpa conditions")
+	}
+
+	if hpax.Spec.Fallback != nil && hpax.Spec.Fallback.Recovery != nil && (previousTier != nil || hpax.Status.FallbackRecovery != nil) {
+		// The hpa has been healthy since its ScalingActive condition became True, unless that was before the fallback
+		// was engaged, in which case the fallback was engaged by another condition.
+		recovered := cond != nil && cond.Status == corev1.ConditionTrue &&
+			(hpax.Status.FallbackEngagedAt == nil || !cond.LastTransitionTime.Before(hpax.Status.FallbackEngagedAt))
+		healthySince := now
+		if recovered {
+			healthySince = cond.LastTransitionTime.Time
+		}
+		if hpax.Status.FallbackRecovery == nil {
+			hpax.Status.FallbackRecovery = &autoscalingxv1.FallbackRecoveryStatus{
+				HealthySince:    metav1.Time{Time: healthySince},
+				FromMinReplicas: tierMinReplicas(hpax.Spec.Fallback, *previousTier),
+			}
+		}
+		if !deadline.IsZero() {
+			// A trigger matches again, so the hpa hasn't been healthy continuously.
+			hpax.Status.FallbackRecovery.HealthySince = metav1.Time{Time: now}
+		} else if recovered && healthySince.After(hpax.Status.FallbackRecovery.HealthySince.Time) {
+			// The hpa became healthy again after a trigger last matched.
+			hpax.Status.FallbackRecovery.HealthySince = metav1.Time{Time: healthySince}
+		}
+
+		minReplicas, next := stepDown(hpax.Spec.Fallback.Recovery, hpax.Status.FallbackRecovery, hpax.Spec.MinReplicas, now)
+		if !next.IsZero() {
+			r.setCondition(hpax, autoscalingxv1.ConditionFallbackActive, corev1.ConditionTrue, "Recovering", "no fallback trigger fires but the hpa is still recovering")
+			fallbackEngagedGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(1)
+			return max(minReplicas, hpax.Spec.MinReplicas), earliest(next, deadline)
+		}
+	}
+	hpax.Status.FallbackRecovery = nil
+	hpax.Status.FallbackEngagedAt = nil
+
+	r.setCondition(hpax, autoscalingxv1.ConditionFallbackActive, corev1.ConditionFalse, "NoTriggerFired", "no fallback trigger matches the hpa conditions for long enough")
+	fallbackEngagedGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(0)
+	return hpax.Spec.MinReplicas, deadline
+}
+
+// fallbackTier returns the minReplicas of the highest fallback tier that applies after the hpa has been failing for
+// the given duration, and that tier, which is 0 for the base fallback and i for Tiers[i-1]. It also returns the
+// failing duration after which the next tier applies, or zero if there is none.
+func fallbackTier(fallback *autoscalingxv1.Fallback, failing time.Duration) (int32, int32, time.Duration) {
+	minReplicas, tier := fallback.MinReplicas, int32(0)
+	for i, next := range fallback.Tiers {
+		if next.After.Duration > failing {
+			return minReplicas, tier, next.After.Duration
+		}
+		minReplicas, tier = max(minReplicas, next.MinReplicas), int32(i+1)
+	}
+	return minReplicas, tier, 0
+}
+
+// tierMinReplicas returns the minReplicas of the given fallback tier, which is 0 for the base fallback and i for Tiers[i-1].
+func tierMinReplicas(fallback *autoscalingxv1.Fallback, tier int32) int32 {
+	minReplicas := fallback.MinReplicas
+	for i := 0; i < int(tier) && i < len(fallback.Tiers); i++ {
+		minReplicas = max(minReplicas, fallback.Tiers[i].MinReplicas)
+	}
+	return minReplicas
+}
+
+// stepDown returns the minReplicas while the hpa recovers from the fallback, which holds the fallback minReplicas for
+// the recovery duration and then steps it down to the base minReplicas. It also returns the time at which the
+// minReplicas next changes, or the zero time once the recovery is over.
+func stepDown(recovery *autoscalingxv1.FallbackRecovery, status *autoscalingxv1.FallbackRecoveryStatus, baseMinReplicas int32, now time.Time) (int32, time.Time) {
+	releaseTime := status.HealthySince.Add(recovery.Duration.Duration)
+	if now.Before(releaseTime) {
+		return status.FromMinReplicas, releaseTime
+	}
+	if recovery.StepDown == nil || status.FromMinReplicas <= baseMinReplicas {
+		return baseMinReplicas, time.Time{}
+	}
+
+	// The first step is taken once the recovery duration has passed, and every interval after that.
+	steps := int64(max(recovery.StepDown.Steps, 1))
+	interval := recovery.StepDown.Interval.Duration
+	step := int64(1)
+	if interval > 0 {
+		step += int64(now.Sub(releaseTime) / interval)
+	}
+	if step >= steps {
+		return baseMinReplicas, time.Time{}
+	}
+
+	from, to := int64(status.FromMinReplicas), int64(baseMinReplicas)
+	minReplicas := from - (from-to)*step/steps
+	return int32(minReplicas), releaseTime.Add(time.Duration(step) * interval)
+}
+
+// fallbackTriggers returns the triggers of the Fallback, which default to the ScalingActive condition being False
+// for the fallback duration.
+func fallbackTriggers(fallback *autoscalingxv1.Fallback) []autoscalingxv1.FallbackTrigger {
+	if len(fallback.Triggers) > 0 {
+		return fallback.Triggers
+	}
+	return []autoscalingxv1.FallbackTrigger{{
+		Type:     autoscalingv2.ScalingActive,
+		Status:   corev1.ConditionFalse,
+		Duration: fallback.Duration,
+	}}
+}
+
+// describeFallbackTrigger returns a human-readable description of the FallbackTrigger, e.g. ScalingActive=False.
+func describeFallbackTrigger(trigger autoscalingxv1.FallbackTrigger) string {
+	description := fmt.Sprintf("%s=%s", trigger.Type, trigger.Status)
+	if trigger.Reason != "" {
+		description += fmt.Sprintf(" (%s)", trigger.Reason)
+	}
+	return description
+}
+
+// getHPACondition returns the condition of the given type of the hpa, or nil if it isn't set.
+func getHPACondition(hpa *autoscalingv2.HorizontalPodAutoscaler, conditionType autoscalingv2.HorizontalPodAutoscalerConditionType) *autoscalingv2.HorizontalPodAutoscalerCondition {
+	for i := range hpa.Status.Conditions {
+		if hpa.Status.Conditions[i].Type == conditionType {
+			return &hpa.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// getOverrideSuggestion calculates the candidate minReplicas and the desired maxReplicas for the HorizontalPodAutoscalerX
+// based on the active HPAOverrides for the hpa. There is a candidate for every active HPAOverride, split into the
+// ScaleUp and the ScaleDown ones, each with the selected one first. The maxReplicas is nil if no active HPAOverride
+// sets it. It also returns the time at which the next HPAOverride is applied, steps its ramp, starts or expires, or the
+// zero time if there is none.
+func (r *HorizontalPodAutoscalerXReconciler) getOverrideSuggestion(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) ([]autoscalingxv1.MinReplicasCandidate, []autoscalingxv1.MinReplicasCandidate, *int32, time.Time) {
+	hpaOverrides, err := listOverridesForHPAX(ctx, r, hpax)
+	if err != nil {
+		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionFalse, "FailedToGetHPAOverride", "failed getting target hpa overrides")
+		return nil, nil, nil, time.Time{}
+	}
+	now := r.Clock.Now()
+	var nextTransition time.Time
+	observe, inUse := false, false
+	for _, hpaOverride := range hpaOverrides {
+		reach, start, end, ok := r.startupLatencyWindow(&hpaOverride, now)
+		if !ok || !now.Before(end) {
+			continue
+		}
+		if now.Before(reach) {
+			nextTransition = earliest(nextTransition, reach)
+			continue
+		}
+		inUse = true
+		observe = observe || now.Before(start)
+	}
+	switch {
+	case observe:
+		if err := r.observeStartupLatency(ctx, hpax, hpa); err != nil {
+			log.FromContext(ctx).Error(err, "observing startup latency, keeping the previous one")
+		}
+	case !inUse:
+		// The startup latency is kept at its highest only for the windows it is observed for.
+		hpax.Status.StartupLatency = nil
+	}
+	hpaOverrides = leadOverrides(hpax, hpaOverrides)
+
+	for _, hpaOverride := range hpaOverrides {
+		_, transition, err := overridePhase(&hpaOverride, now)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "evaluating override window, ignoring override", "hpaoverride", hpaOverride.Name)
 			continue
 		}
-		if hpaOverride.Spec.Time.Add(hpaOverride.Spec.Duration.Duration).Before(now) {
+		nextTransition = earliest(nextTransition, transition)
+	}
+
+	hpaOverrides, hpax.Status.ResolvedOverrides = resolveOverrides(hpax, hpa, hpaOverrides, now)
+	nextTransition = earliest(nextTransition, r.checkOverrideReadiness(ctx, hpax, hpa, hpaOverrides, now))
+	hpaOverrides, nextStep := rampOverrides(hpax, hpaOverrides, now)
+	nextTransition = earliest(nextTransition, nextStep)
+
+	winner := selectOverride(hpaOverrides, hpax.Spec.OverrideStrategy, now)
+	scaleDownWinner := selectScaleDownOverride(hpaOverrides, now)
+	var scaleUpCandidates, scaleDownCandidates []autoscalingxv1.MinReplicasCandidate
+	var maxReplicas *int32
+	for _, hpaOverride := range hpaOverrides {
+		if phase, _, err := overridePhase(&hpaOverride, now); err != nil || phase != autoscalingxv1.HPAOverridePhaseActive {
 			continue
 		}
-		replicas = append(replicas, hpaOverride.Spec.MinReplicas)
+		candidate := overrideCandidate(&hpaOverride)
+		switch {
+		case overrideType(&hpaOverride) == autoscalingxv1.OverrideTypeScaleDown && overrideKey(&hpaOverride) == overrideKey(scaleDownWinner):
+			scaleDownCandidates = slices.Insert(scaleDownCandidates, 0, candidate)
+		case overrideType(&hpaOverride) == autoscalingxv1.OverrideTypeScaleDown:
+			scaleDownCandidates = append(scaleDownCandidates, candidate)
+		case overrideKey(&hpaOverride) == overrideKey(winner):
+			scaleUpCandidates = slices.Insert(scaleUpCandidates, 0, candidate)
+		default:
+			scaleUpCandidates = append(scaleUpCandidates, candidate)
+		}
+		// Overlapping overrides raise the ceiling to the highest maxReplicas among them.
+		if hpaOverride.Spec.MaxReplicas != nil && (maxReplicas == nil || *hpaOverride.Spec.MaxReplicas > *maxReplicas) {
+			maxReplicas = ptr.To(*hpaOverride.Spec.MaxReplicas)
+		}
 	}
+	activeOverridesGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(len(scaleUpCandidates) + len(scaleDownCandidates)))
 
-	if len(replicas) == 0 {
+	if winner == nil && scaleDownWinner == nil {
 		r.setCondition(hpax, autoscalingxv1.ConditionOverrideActive, corev1.ConditionFalse, "NoActiveOverride", "no active override was found")
-		return hpax.Spec.MinReplicas
+		return nil, nil, maxReplicas, nextTransition
 	}
 
 	r.setCondition(hpax, autoscalingxv1.ConditionOverrideActive, corev1.ConditionTrue, "OverrideActive", "an override that is active was found")
-	return slices.Max(replicas)
+	return scaleUpCandidates, scaleDownCandidates, maxReplicas, nextTransition
+}
+
+// overrideCandidate returns the candidate minReplicas of the HPAOverride, which is a ClusterHPAOverride if it has no
+// namespace.
+func overrideCandidate(hpaOverride *autoscalingxv1.HPAOverride) autoscalingxv1.MinReplicasCandidate {
+	source := autoscalingxv1.CandidateSourceHPAOverride
+	if hpaOverride.Namespace == "" {
+		source = autoscalingxv1.CandidateSourceClusterHPAOverride
+	}
+	return autoscalingxv1.MinReplicasCandidate{Source: source, Name: hpaOverride.Name, MinReplicas: hpaOverride.Spec.MinReplicas}
+}
+
+// checkOverrideReadiness records whether the scale target of the hpa had the ready replicas requested by each active
+// HPAOverride with a lead time once it starts, once per window. It returns the time at which the next of these
+// HPAOverrides starts, or the zero time if there is none.
+func (r *HorizontalPodAutoscalerXReconciler) checkOverrideReadiness(
+	ctx context.Context,
+	hpax *autoscalingxv1.HorizontalPodAutoscalerX,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+	hpaOverrides []autoscalingxv1.HPAOverride,
+	now time.Time,
+) time.Time {
+	var readiness []autoscalingxv1.OverrideReadiness
+	var nextStart time.Time
+	for _, hpaOverride := range hpaOverrides {
+		if hpaOverride.Spec.LeadTime == nil {
+			continue
+		}
+		if phase, _, err := overridePhase(&hpaOverride, now); err != nil || phase != autoscalingxv1.HPAOverridePhaseActive {
+			continue
+		}
+		start, _, _ := overrideWindow(&hpaOverride, now)
+		if now.Before(start) {
+			nextStart = earliest(nextStart, start)
+			continue
+		}
+
+		key := overrideKey(&hpaOverride)
+		if i := slices.IndexFunc(hpax.Status.OverrideReadiness, func(previous autoscalingxv1.OverrideReadiness) bool {
+			return previous.Name == key && previous.StartTime.Time.Equal(start)
+		}); i >= 0 {
+			readiness = append(readiness, hpax.Status.OverrideReadiness[i])
+			continue
+		}
+		readyReplicas, err := r.getReadyReplicas(ctx, hpa)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "getting ready replicas, not checking override readiness", "hpaoverride", key)
+			continue
+		}
+		overrideReadiness := autoscalingxv1.OverrideReadiness{
+			Name:          key,
+			StartTime:     metav1.Time{Time: start},
+			MinReplicas:   hpaOverride.Spec.MinReplicas,
+			ReadyReplicas: readyReplicas,
+			Ready:         readyReplicas >= hpaOverride.Spec.MinReplicas,
+		}
+		if !overrideReadiness.Ready {
+			r.EventRecorder.Eventf(hpax, corev1.EventTypeWarning, "OverrideNotReady", "scale target had %d of the %d ready replicas requested by override %s when it started",
+				readyReplicas, hpaOverride.Spec.MinReplicas, key)
+		}
+		readiness = append(readiness, overrideReadiness)
+	}
+	hpax.Status.OverrideReadiness = readiness
+	return nextStart
+}
+
+// getReadyReplicas returns the number of ready replicas of the scale target of the hpa.
+func (r *HorizontalPodAutoscalerXReconciler) getReadyReplicas(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) (int32, error) {
+	target, err := r.getScaleTarget(ctx, hpa)
+	if err != nil {
+		return 0, err
+	}
+	readyReplicas, _, err := unstructured.NestedInt64(target.Object, "status", "readyReplicas")
+	if err != nil {
+		return 0, fmt.Errorf("reading ready replicas of scale target %s %q: %w", target.GetKind(), target.GetName(), err)
+	}
+	return int32(readyReplicas), nil
+}
+
+// startupLatencyWindow returns the current or next window of the HPAOverride if it derives its lead time from the
+// startup latency, along with the time it comes within reach of the window, i.e. the earliest it could start applying
+// with the longest startup latency. It returns false if the override doesn't derive its lead time from the startup
+// latency.
+func (r *HorizontalPodAutoscalerXReconciler) startupLatencyWindow(hpaOverride *autoscalingxv1.HPAOverride, now time.Time) (time.Time, time.Time, time.Time, bool) {
+	if hpaOverride.Spec.LeadTime == nil || !hpaOverride.Spec.LeadTime.FromStartupLatency {
+		return time.Time{}, time.Time{}, time.Time{}, false
+	}
+	start, end, err := overrideWindow(hpaOverride, now)
+	if err != nil || start.IsZero() {
+		return time.Time{}, time.Time{}, time.Time{}, false
+	}
+	reach := start.Add(-leadTime(hpaOverride) - r.MaxStartupLatency - rampDuration(hpaOverride.Spec.WarmUp))
+	return reach, start, end, true
+}
+
+// observeStartupLatency records the highest time from creation to ready of the ready pods of the scale target of the
+// hpa. Pods whose containers restarted or that became ready more than MaxStartupLatency after their creation are
+// ignored, since their readiness no longer tells how long they took to start. It only raises the previously observed
+// startup latency, so that pods created since, e.g. while the override scales up, don't shorten the lead time.
+func (r *HorizontalPodAutoscalerXReconciler) observeStartupLatency(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	target, err := r.getScaleTarget(ctx, hpa)
+	if err != nil {
+		return err
+	}
+	rawSelector, found, err := unstructured.NestedMap(target.Object, "spec", "selector")
+	if err != nil || !found {
+		return fmt.Errorf("scale target %s %q has no selector", target.GetKind(), target.GetName())
+	}
+	labelSelector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, labelSelector); err != nil {
+		return fmt.Errorf("converting selector of scale target %s %q: %w", target.GetKind(), target.GetName(), err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return fmt.Errorf("parsing selector of scale target %s %q: %w", target.GetKind(), target.GetName(), err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, pods, client.InNamespace(hpa.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("listing pods of scale target %s %q: %w", target.GetKind(), target.GetName(), err)
+	}
+	var latency time.Duration
+	for _, pod := range pods.Items {
+		if slices.ContainsFunc(pod.Status.ContainerStatuses, func(status corev1.ContainerStatus) bool {
+			return status.RestartCount > 0
+		}) {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodReady || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if podLatency := condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time); podLatency <= r.MaxStartupLatency {
+				latency = max(latency, podLatency)
+			}
+		}
+	}
+	if latency > 0 && (hpax.Status.StartupLatency == nil || latency > hpax.Status.StartupLatency.Duration) {
+		hpax.Status.StartupLatency = &metav1.Duration{Duration: latency}
+	}
+	return nil
+}
+
+// getScaleTarget gets the scale target of the hpa, e.g. a Deployment. It is read as unstructured, which isn't cached.
+func (r *HorizontalPodAutoscalerXReconciler) getScaleTarget(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) (*unstructured.Unstructured, error) {
+	ref := hpa.Spec.ScaleTargetRef
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion(ref.APIVersion)
+	target.SetKind(ref.Kind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hpa.Namespace, Name: ref.Name}, target); err != nil {
+		return nil, fmt.Errorf("getting scale target %s %q: %w", ref.Kind, ref.Name, err)
+	}
+	return target, nil
 }
 
-func (r *HorizontalPodAutoscalerXReconciler) updateHpaMinReplicas(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
-	minReplicas := slices.Max([]int32{hpax.Spec.MinReplicas, r.getFallbackSuggestion(hpax, hpa), r.getOverrideSuggestion(ctx, hpax)})
+// selectCandidate returns the candidate with the highest minReplicas, the earliest one if there is a tie.
+func selectCandidate(candidates []autoscalingxv1.MinReplicasCandidate) autoscalingxv1.MinReplicasCandidate {
+	winner := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.MinReplicas > winner.MinReplicas {
+			winner = candidate
+		}
+	}
+	return winner
+}
+
+// updateHpaReplicas patches the HPA spec.minReplicas to the max of the base, floor, fallback and override suggestions,
+// and the HPA spec.maxReplicas to the override suggestion, or otherwise the base maxReplicas, or otherwise the
+// maxReplicas of the hpaTemplate, or otherwise the maxReplicas the HPA had before it was adopted. The minReplicas is capped at the maxReplicas.
+// It returns the time at which the suggestions will next change on their own, or the zero time if they won't.
+func (r *HorizontalPodAutoscalerXReconciler) updateHpaReplicas(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) (time.Time, error) {
+	fallbackReplicas, fallbackTransition := r.getFallbackSuggestion(hpax, hpa)
+	scaleUpCandidates, scaleDownCandidates, overrideMaxReplicas, overrideTransition := r.getOverrideSuggestion(ctx, hpax, hpa)
+	overrideReplicas := hpax.Spec.MinReplicas
+	switch {
+	case len(scaleUpCandidates) > 0:
+		overrideReplicas = scaleUpCandidates[0].MinReplicas
+	case len(scaleDownCandidates) > 0:
+		overrideReplicas = scaleDownCandidates[0].MinReplicas
+	}
+
+	candidates := []autoscalingxv1.MinReplicasCandidate{{Source: autoscalingxv1.CandidateSourceBaseMinReplicas, MinReplicas: hpax.Spec.MinReplicas}}
+	if hpax.Spec.FloorMinReplicas != nil {
+		candidates = append(candidates, autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceFloorMinReplicas, MinReplicas: *hpax.Spec.FloorMinReplicas})
+	}
+	if hpax.Status.FallbackEngagedAt != nil {
+		candidates = append(candidates, autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceFallback, MinReplicas: fallbackReplicas})
+	}
+	// With the Max override strategy every candidate competes. Otherwise the selected override replaces the base
+	// minReplicas, so that it can also lower it. The selected ScaleDown override always replaces the base
+	// minReplicas. The floor and the fallback compete either way.
+	competing := append(slices.Clone(candidates), scaleUpCandidates...)
+	if strategy := hpax.Spec.OverrideStrategy; strategy != "" && strategy != autoscalingxv1.OverrideStrategyMax && len(scaleUpCandidates) > 0 {
+		competing = append(slices.Clone(candidates[1:]), scaleUpCandidates[0])
+	}
+	if len(scaleDownCandidates) > 0 {
+		competing = slices.DeleteFunc(competing, func(candidate autoscalingxv1.MinReplicasCandidate) bool {
+			return candidate.Source == autoscalingxv1.CandidateSourceBaseMinReplicas
+		})
+		competing = append(competing, scaleDownCandidates[0])
+	}
+	candidates = append(append(candidates, scaleUpCandidates...), scaleDownCandidates...)
+	winner := selectCandidate(competing)
+	hpax.Status.Candidates = candidates
+	hpax.Status.Winner = &winner
+	minReplicas := winner.MinReplicas
+	reason := string(winner.Source)
+	if minReplicas < 1 && !r.ScaleToZero {
+		r.EventRecorder.Event(hpax, corev1.EventTypeWarning, "RaisedMinReplicas", "raised minReplicas 0 to 1 since scaling to zero is not enabled")
+		minReplicas = 1
+		reason = "ScaleToZeroDisabled"
+	}
+
+	maxReplicas := hpa.Spec.MaxReplicas
+	originalMaxReplicas, err := getReplicasAnnotation(hpa, OriginalMaxReplicasAnnotation)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Unless something sets the maxReplicas, the HPA's own maxReplicas is left alone, except that it is restored once
+	// after whatever set it stops applying.
+	setsMaxReplicas, restoresMaxReplicas := true, false
+	switch {
+	case overrideMaxReplicas != nil:
+		maxReplicas = *overrideMaxReplicas
+	case hpax.Spec.MaxReplicas != nil:
+		maxReplicas = *hpax.Spec.MaxReplicas
+	case hpax.Spec.HPATemplate != nil:
+		maxReplicas = hpax.Spec.HPATemplate.MaxReplicas
+	case originalMaxReplicas != nil:
+		maxReplicas = *originalMaxReplicas
+		setsMaxReplicas, restoresMaxReplicas = false, true
+	default:
+		setsMaxReplicas = false
+	}
+	if minReplicas > maxReplicas {
+		r.EventRecorder.Eventf(hpax, corev1.EventTypeWarning, "CappedMinReplicas", "capped minReplicas %d at maxReplicas %d", minReplicas, maxReplicas)
+		minReplicas = maxReplicas
+		reason = "CappedAtMaxReplicas"
+	}
+
+	baseMinReplicasGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(hpax.Spec.MinReplicas))
+	fallbackMinReplicasGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(fallbackReplicas))
+	overrideMinReplicasGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(overrideReplicas))
+	minReplicasGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(minReplicas))
+	maxReplicasGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(float64(maxReplicas))
+
+	if r.isDryRun(hpax) {
+		dryRunGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(1)
+		dryRun := &autoscalingxv1.DryRunStatus{
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+			Reason:      reason,
+			HPATemplate: r.hpaTemplateAction(hpax, hpa),
+		}
+		if !apiequality.Semantic.DeepEqual(hpax.Status.DryRun, dryRun) {
+			r.EventRecorder.Eventf(hpax, corev1.EventTypeNormal, "DryRun", "would update the hpa to minReplicas %d and maxReplicas %d (%s)",
+				minReplicas, maxReplicas, reason)
+			if dryRun.HPATemplate != "" {
+				r.EventRecorder.Eventf(hpax, corev1.EventTypeNormal, "DryRun", "would %s the hpa %s from the hpaTemplate",
+					strings.ToLower(dryRun.HPATemplate), hpa.Name)
+			}
+		}
+		hpax.Status.DryRun = dryRun
+		return earliest(fallbackTransition, overrideTransition), nil
+	}
+	dryRunGauge.WithLabelValues(hpax.Namespace, hpax.Name).Set(0)
+	hpax.Status.DryRun = nil
 
 	hpaCopy := hpa.DeepCopy()
+	if restoresMaxReplicas {
+		// Leaving the annotation out of the apply removes it, so the maxReplicas is only restored once.
+		delete(hpa.Annotations, OriginalMaxReplicasAnnotation)
+	}
+	// Record the replicas from before the HPA was adopted in the same patch that first changes them.
+	if _, ok := hpa.Annotations[OriginalMinReplicasAnnotation]; !ok && hpa.Spec.MinReplicas != nil {
+		metav1.SetMetaDataAnnotation(&hpa.ObjectMeta, OriginalMinReplicasAnnotation, strconv.Itoa(int(*hpa.Spec.MinReplicas)))
+	}
+	if setsMaxReplicas && originalMaxReplicas == nil && maxReplicas != hpa.Spec.MaxReplicas {
+		metav1.SetMetaDataAnnotation(&hpa.ObjectMeta, OriginalMaxReplicasAnnotation, strconv.Itoa(int(hpa.Spec.MaxReplicas)))
+	}
 	hpa.Spec.MinReplicas = &minReplicas
-	err := r.Patch(ctx, hpa, client.StrategicMergeFrom(hpaCopy))
+	hpa.Spec.MaxReplicas = maxReplicas
+	// Leaving the maxReplicas out of the apply gives up its ownership, unless no other field manager owns it, in which
+	// case leaving it out would remove it.
+	withMaxReplicas := setsMaxReplicas || restoresMaxReplicas || !ownedByOthers(hpa, FieldManager, "f:spec", "f:maxReplicas")
+	// Skip the write if the HPA is already up to date, unless it has never been applied so that it is adopted.
+	if apiequality.Semantic.DeepEqual(hpaCopy, hpa) && isApplyManager(hpa, FieldManager) {
+		writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "hpa", "skipped").Inc()
+	} else {
+		writesCounter.WithLabelValues(hpax.Namespace, hpax.Name, "hpa", "performed").Inc()
+		applied, err := r.applyHPAReplicas(ctx, hpax, hpa, withMaxReplicas)
+		if err != nil {
+			hpaPatchFailuresCounter.WithLabelValues(hpax.Namespace, hpax.Name).Inc()
+			r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionFalse, "FailedToUpdateHPA", "failed updating the target hpa spec.minReplicas and spec.maxReplicas")
+			return time.Time{}, err
+		}
+		if !applied {
+			return earliest(fallbackTransition, overrideTransition), nil
+		}
+	}
+
+	if hpax.Status.LastAppliedTime == nil || !ptr.Equal(hpaCopy.Spec.MinReplicas, hpa.Spec.MinReplicas) || hpaCopy.Spec.MaxReplicas != hpa.Spec.MaxReplicas {
+		hpax.Status.LastAppliedTime = &metav1.Time{Time: r.Clock.Now()}
+	}
+	hpax.Status.MinReplicas = ptr.To(minReplicas)
+	return earliest(fallbackTransition, overrideTransition), nil
+}
+
+// applyHPAReplicas server-side applies the minReplicas, the maxReplicas if withMaxReplicas is set, and the original
+// replicas annotations of the HPA as the FieldManager. If another field manager owns a conflicting minReplicas or
+// maxReplicas, it takes ownership from them or backs off according to the conflictPolicy of the
+// HorizontalPodAutoscalerX, and returns false if it backed off. The first apply always takes ownership, since whoever
+// created the HPA owns its defaulted minReplicas, and so does an apply that only conflicts with the controller's own
+// field managers, e.g. the TemplateFieldManager owning the maxReplicas of the hpaTemplate.
+func (r *HorizontalPodAutoscalerXReconciler) applyHPAReplicas(
+	ctx context.Context,
+	hpax *autoscalingxv1.HorizontalPodAutoscalerX,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+	withMaxReplicas bool,
+) (bool, error) {
+	annotations := map[string]string{}
+	for _, key := range []string{OriginalMinReplicasAnnotation, OriginalMaxReplicasAnnotation} {
+		if value, ok := hpa.Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+	patch, err := hpaReplicasApply(hpa, annotations, withMaxReplicas)
+	if err != nil {
+		return false, err
+	}
+
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if !isApplyManager(hpa, FieldManager) {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err = r.Patch(ctx, patch, client.Apply, opts...)
+	if !apierrors.IsConflict(err) {
+		if err == nil {
+			r.setCondition(hpax, autoscalingxv1.ConditionConflict, corev1.ConditionFalse, "NoConflict", "no other field manager owns a conflicting spec.minReplicas or spec.maxReplicas")
+		}
+		return err == nil, err
+	}
+
+	foreignManagers := slices.DeleteFunc(conflictingManagers(err), isOwnManager)
+	if len(foreignManagers) == 0 {
+		r.setCondition(hpax, autoscalingxv1.ConditionConflict, corev1.ConditionFalse, "NoConflict", "no other field manager owns a conflicting spec.minReplicas or spec.maxReplicas")
+		return true, r.Patch(ctx, patch, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+	managers := strings.Join(foreignManagers, ", ")
+	if hpax.Spec.ConflictPolicy == autoscalingxv1.ConflictPolicyBackOff {
+		message := fmt.Sprintf("backed off from the hpa spec.minReplicas and spec.maxReplicas owned by %s", managers)
+		r.setCondition(hpax, autoscalingxv1.ConditionConflict, corev1.ConditionTrue, "BackedOff", message)
+		r.EventRecorder.Event(hpax, corev1.EventTypeWarning, "Conflict", message)
+		return false, nil
+	}
+
+	message := fmt.Sprintf("took ownership of the hpa spec.minReplicas and spec.maxReplicas from %s", managers)
+	r.setCondition(hpax, autoscalingxv1.ConditionConflict, corev1.ConditionTrue, "ForcedOwnership", message)
+	r.EventRecorder.Event(hpax, corev1.EventTypeWarning, "Conflict", message)
+	return true, r.Patch(ctx, patch, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+}
+
+// hpaReplicasApply returns the server-side apply configuration with only the fields of the HPA the FieldManager owns:
+// its minReplicas, its maxReplicas if withMaxReplicas is set, and the given annotations.
+func hpaReplicasApply(hpa *autoscalingv2.HorizontalPodAutoscaler, annotations map[string]string, withMaxReplicas bool) (*unstructured.Unstructured, error) {
+	spec := autoscalingv2apply.HorizontalPodAutoscalerSpec()
+	if withMaxReplicas {
+		spec = spec.WithMaxReplicas(hpa.Spec.MaxReplicas)
+	}
+	if hpa.Spec.MinReplicas != nil {
+		spec = spec.WithMinReplicas(*hpa.Spec.MinReplicas)
+	}
+	hpaApply := autoscalingv2apply.HorizontalPodAutoscaler(hpa.Name, hpa.Namespace).WithSpec(spec)
+	if len(annotations) > 0 {
+		hpaApply = hpaApply.WithAnnotations(annotations)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hpaApply)
 	if err != nil {
-		r.setCondition(hpax, autoscalingxv1.ConditionReady, corev1.ConditionFalse, "FailedToUpdateHPA", "failed updating the target hpa spec.minReplicas")
+		return nil, fmt.Errorf("converting the hpa apply configuration: %w", err)
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// ownedByOthers returns whether a field manager other than the apply of the given field manager owns the field of the
+// HPA at the given path, e.g. "f:spec", "f:maxReplicas", so that leaving it out of the apply doesn't remove it. The
+// controller's other field manager counts, since it keeps the field as much as any other.
+func ownedByOthers(hpa *autoscalingv2.HorizontalPodAutoscaler, manager string, path ...string) bool {
+	return slices.ContainsFunc(hpa.ManagedFields, func(entry metav1.ManagedFieldsEntry) bool {
+		if entry.Manager == manager && entry.Operation == metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			return false
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return false
+		}
+		for _, key := range path {
+			next, ok := fields[key].(map[string]any)
+			if !ok {
+				return false
+			}
+			fields = next
+		}
+		return true
+	})
+}
+
+// isOwnManager returns whether the field manager is one of the controller's own, whose conflicts it resolves by taking
+// ownership regardless of the conflictPolicy.
+func isOwnManager(manager string) bool {
+	return manager == FieldManager || manager == TemplateFieldManager
+}
+
+// isApplyManager returns whether the field manager has server-side applied the HPA.
+func isApplyManager(hpa *autoscalingv2.HorizontalPodAutoscaler, manager string) bool {
+	return slices.ContainsFunc(hpa.ManagedFields, func(entry metav1.ManagedFieldsEntry) bool {
+		return entry.Manager == manager && entry.Operation == metav1.ManagedFieldsOperationApply
+	})
+}
+
+// conflictingManagers returns the sorted field managers named by the causes of a server-side apply conflict, whose
+// messages look like: conflict with "kubectl-edit" using autoscaling/v2.
+func conflictingManagers(err error) []string {
+	var managers []string
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return managers
+	}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		_, manager, _ := strings.Cut(cause.Message, `"`)
+		manager, _, _ = strings.Cut(manager, `"`)
+		if !slices.Contains(managers, manager) {
+			managers = append(managers, manager)
+		}
+	}
+	slices.Sort(managers)
+	return managers
+}
+
+// isDryRun returns whether the HorizontalPodAutoscalerX computes the replicas of its HPA without updating it.
+func (r *HorizontalPodAutoscalerXReconciler) isDryRun(hpax *autoscalingxv1.HorizontalPodAutoscalerX) bool {
+	return r.DryRun || hpax.Spec.Mode == autoscalingxv1.ModeDryRun
+}
+
+// finalize releases the HPA targeted by the HorizontalPodAutoscalerX and removes the finalizer.
+func (r *HorizontalPodAutoscalerXReconciler) finalize(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) error {
+	if !controllerutil.ContainsFinalizer(hpax, Finalizer) {
+		return nil
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := r.Get(ctx, client.ObjectKey{Name: hpax.Spec.HPATargetName, Namespace: hpax.Namespace}, hpa)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("getting HPA: %w", err)
+	}
+	if err == nil {
+		if err := r.releaseHPA(ctx, hpax, hpa); err != nil {
+			return fmt.Errorf("releasing HPA: %w", err)
+		}
+	}
+
+	deleteMetrics(hpax.Namespace, hpax.Name)
+	controllerutil.RemoveFinalizer(hpax, Finalizer)
+	return r.Update(ctx, hpax)
+}
+
+// releaseHPA patches the HPA spec.minReplicas back to the release minReplicas of the HorizontalPodAutoscalerX,
+// or otherwise to the minReplicas it had before it was adopted, and the HPA spec.maxReplicas back to the
+// maxReplicas it had before it was adopted.
+func (r *HorizontalPodAutoscalerXReconciler) releaseHPA(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+	minReplicas, err := getReplicasAnnotation(hpa, OriginalMinReplicasAnnotation)
+	if err != nil {
+		return err
+	}
+	if hpax.Spec.ReleaseMinReplicas != nil {
+		minReplicas = hpax.Spec.ReleaseMinReplicas
+	}
+	maxReplicas, err := getReplicasAnnotation(hpa, OriginalMaxReplicasAnnotation)
+	if err != nil {
+		return err
+	}
+	if minReplicas == nil && maxReplicas == nil {
+		// The HPA was never updated, leave it as is.
+		return nil
+	}
+
+	if minReplicas != nil {
+		hpa.Spec.MinReplicas = minReplicas
+	}
+	if maxReplicas != nil {
+		hpa.Spec.MaxReplicas = *maxReplicas
+	}
+	if hpa.Spec.MinReplicas != nil && *hpa.Spec.MinReplicas > hpa.Spec.MaxReplicas {
+		hpa.Spec.MinReplicas = ptr.To(hpa.Spec.MaxReplicas)
+	}
+	// Leaving out the annotations removes them, as the FieldManager applied them. The apply carries no
+	// resourceVersion and forces ownership, so unlike an update it can't fail with a conflict when the hpaTemplate or
+	// anyone else changed the HPA in the meantime, and there is nothing to retry.
+	patch, err := hpaReplicasApply(hpa, nil, true)
+	if err != nil {
+		return err
+	}
+	if err := r.Patch(ctx, patch, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+
+	r.EventRecorder.Eventf(hpax, corev1.EventTypeNormal, "ReleasedHPA", "released the hpa with minReplicas %d and maxReplicas %d",
+		ptr.Deref(hpa.Spec.MinReplicas, 1), hpa.Spec.MaxReplicas)
+	return nil
+}
+
+// getReplicasAnnotation parses the replicas recorded in the given annotation of the HPA, or returns nil if it isn't set.
+func getReplicasAnnotation(hpa *autoscalingv2.HorizontalPodAutoscaler, annotation string) (*int32, error) {
+	value, ok := hpa.Annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s annotation: %w", annotation, err)
+	}
+	return ptr.To(int32(parsed)), nil
+}
+
+// earliest returns the earliest of the given times, ignoring zero times.
+func earliest(times ...time.Time) time.Time {
+	var t time.Time
+	for _, candidate := range times {
+		if candidate.IsZero() {
+			continue
+		}
+		if t.IsZero() || candidate.Before(t) {
+			t = candidate
+		}
 	}
-	return err
+	return t
 }
@@ -2,16 +2,20 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
@@ -19,6 +23,7 @@ import (
 
 const (
 	eventuallyTimeout   = 2 * time.Second
+	requeueTimeout      = 4 * time.Second
 	consistentlyTimeout = 4 * time.Second
 	interval            = 250 * time.Millisecond
 	namespace           = "default"
@@ -78,7 +83,13 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 		AfterEach(func() {
 			By("deleting the HorizontalPodAutoscalerX")
 			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{ObjectMeta: metav1.ObjectMeta{Name: hpaxName, Namespace: namespace}}
-			Expect(k8sClient.Delete(ctx, hpax)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, hpax))).To(Succeed())
+
+			By("waiting for the HorizontalPodAutoscalerX to be finalized")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)
+				return apierrors.IsNotFound(err)
+			}, eventuallyTimeout, interval).Should(BeTrue())
 
 			By("deleting the associated HPA")
 			hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: hpaName, Namespace: namespace}}
@@ -90,6 +101,9 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			for _, hpaOverride := range hpaOverrideList.Items {
 				Expect(k8sClient.Delete(ctx, &hpaOverride)).To(Succeed())
 			}
+
+			By("deleting any ClusterHPAOverride")
+			Expect(k8sClient.DeleteAllOf(ctx, &autoscalingxv1.ClusterHPAOverride{})).To(Succeed())
 		})
 
 		It("should not update minReplicas if scaling active condition is true for short time", func() {
@@ -339,9 +353,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 1 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-2 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-2 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -363,9 +379,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 1 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(2 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(2 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -387,9 +405,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -411,9 +431,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride1 := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override-1", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -423,9 +445,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride2 := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override-2", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 20,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 20,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -447,9 +471,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -501,9 +527,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas - 1,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas - 1,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: hpaName,
 				},
 			}
@@ -525,9 +553,11 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 			hpaOverride := &autoscalingxv1.HPAOverride{
 				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
 				Spec: autoscalingxv1.HPAOverrideSpec{
-					MinReplicas:   fallbackMinReplicas + 10,
-					Duration:      metav1.Duration{Duration: 2 * time.Hour},
-					Time:          metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
 					HPATargetName: "some-other-hpa",
 				},
 			}
@@ -543,5 +573,1764 @@ var _ = Describe("HorizontalPodAutoscalerX Controller", func() {
 				return -1
 			}, consistentlyTimeout, interval).Should(Equal(minReplicas))
 		})
+
+		It("should apply and then expire an override once the clock reaches its start and end", func() {
+			By("creating an override that starts in the future")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(2 * time.Second)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is not updated before the override starts")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(minReplicas))
+
+			By("advancing the clock to the start of the override")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the hpa to check if minReplicas is updated without any other event")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, requeueTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+
+			By("advancing the clock to the end of the override")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the hpa to check if minReplicas is restored without any other event")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, requeueTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should apply the fallback once the clock reaches the fallback deadline", func() {
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false more recently than fallback duration")
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(2 * time.Second)},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is not updated before the deadline")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(minReplicas))
+
+			By("advancing the clock to the fallback deadline")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the hpa to check if minReplicas is updated without any other event")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, requeueTimeout, interval).Should(Equal(fallbackMinReplicas))
+		})
+
+		It("should escalate the fallback tiers as the clock advances", func() {
+			By("setting a fallback tier")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.Fallback.Tiers = []autoscalingxv1.FallbackTier{
+				{MinReplicas: fallbackMinReplicas + 10, After: metav1.Duration{Duration: fallbackDuration + 2*time.Second}},
+			}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false for longer than fallback duration")
+			origHpa := hpa.DeepCopy()
+			lastTransitionTime := fakeclock.Now().Add(-fallbackDuration).Truncate(time.Second)
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: lastTransitionTime},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated to the base fallback")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas))
+
+			By("getting the HorizontalPodAutoscalerX to check the fallback tier")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.FallbackTier).To(Equal(ptr.To(int32(0))))
+				g.Expect(hpax.Status.NextFallbackTierTime).NotTo(BeNil())
+				g.Expect(hpax.Status.NextFallbackTierTime.Time).To(BeTemporally("==", lastTransitionTime.Add(fallbackDuration+2*time.Second)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("advancing the clock to the next tier")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the hpa to check if minReplicas is escalated without any other event")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, requeueTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+
+			By("getting the HorizontalPodAutoscalerX to check the fallback tier")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.FallbackTier).To(Equal(ptr.To(int32(1))))
+				g.Expect(hpax.Status.NextFallbackTierTime).To(BeNil())
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should hold and step down the fallback while the hpa recovers", func() {
+			By("setting a fallback recovery")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.Fallback.Recovery = &autoscalingxv1.FallbackRecovery{
+				Duration: metav1.Duration{Duration: 2 * time.Second},
+				StepDown: &autoscalingxv1.FallbackStepDown{Steps: 3, Interval: metav1.Duration{Duration: time.Second}},
+			}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false for longer than fallback duration")
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration)},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}
+
+			By("getting the hpa to check if minReplicas is updated to the fallback")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas))
+
+			By("updating the hpa status to have scaling active condition as true")
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa = hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now()},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the HorizontalPodAutoscalerX to check that it is recovering")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.FallbackRecovery).NotTo(BeNil())
+				g.Expect(hpax.Status.FallbackRecovery.FromMinReplicas).To(Equal(fallbackMinReplicas))
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionFallbackActive)).To(HaveField("Reason", "Recovering"))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("getting the hpa to check that minReplicas is held during the recovery duration")
+			Consistently(getMinReplicas, consistentlyTimeout, interval).Should(Equal(fallbackMinReplicas))
+
+			By("advancing the clock past the recovery duration and through the step-down")
+			fakeclock.Step(2 * time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(7)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(4)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(minReplicas))
+
+			By("getting the HorizontalPodAutoscalerX to check that the recovery is over")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.FallbackRecovery).To(BeNil())
+				g.Expect(hpax.Status.FallbackEngagedAt).To(BeNil())
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionFallbackActive)).To(HaveField("Status", corev1.ConditionFalse))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should report the fallback conditions through every transition", func() {
+			expectFallback := func(pending, active corev1.ConditionStatus, activeReason string) *autoscalingxv1.HorizontalPodAutoscalerX {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				Eventually(func(g Gomega) {
+					g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+					g.Expect(getCondition(hpax, autoscalingxv1.ConditionFallbackPending)).To(HaveField("Status", pending))
+					g.Expect(getCondition(hpax, autoscalingxv1.ConditionFallbackActive)).To(And(HaveField("Status", active), HaveField("Reason", activeReason)))
+				}, requeueTimeout, interval).Should(Succeed())
+				return hpax
+			}
+
+			By("checking that the fallback is neither pending nor active while the hpa is healthy")
+			hpax := expectFallback(corev1.ConditionFalse, corev1.ConditionFalse, "NoTriggerFired")
+			Expect(hpax.Status.FailureSince).To(BeNil())
+			Expect(hpax.Status.FallbackEngagedAt).To(BeNil())
+
+			By("updating the hpa status to have scaling active condition as false for not long enough")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			failureSince := fakeclock.Now().Add(time.Second - fallbackDuration).Truncate(time.Second)
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: failureSince},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("checking that the fallback is pending")
+			hpax = expectFallback(corev1.ConditionTrue, corev1.ConditionFalse, "NoTriggerFired")
+			Expect(hpax.Status.FailureSince).NotTo(BeNil())
+			Expect(hpax.Status.FailureSince.Time).To(BeTemporally("==", failureSince))
+			Expect(hpax.Status.FallbackEngagedAt).To(BeNil())
+
+			By("advancing the clock past the fallback duration")
+			fakeclock.Step(time.Second)
+
+			By("checking that the fallback is active")
+			hpax = expectFallback(corev1.ConditionFalse, corev1.ConditionTrue, "TriggerFired")
+			Expect(hpax.Status.FailureSince.Time).To(BeTemporally("==", failureSince))
+			Expect(hpax.Status.FallbackEngagedAt).NotTo(BeNil())
+
+			By("updating the hpa status to have scaling active condition as true")
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa = hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now()},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("checking that the fallback is released")
+			hpax = expectFallback(corev1.ConditionFalse, corev1.ConditionFalse, "NoTriggerFired")
+			Expect(hpax.Status.FailureSince).To(BeNil())
+			Expect(hpax.Status.FallbackEngagedAt).To(BeNil())
+		})
+
+		It("should update minReplicas if a recurring override is active", func() {
+			By("creating a recurring override that is always active")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Schedule:    &autoscalingxv1.Schedule{Cron: "0 * * * *"},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+		})
+
+		It("should evaluate a recurring override in its time zone", func() {
+			By("creating a recurring override that started this minute in Europe/Berlin")
+			berlin, err := time.LoadLocation("Europe/Berlin")
+			Expect(err).NotTo(HaveOccurred())
+			now := fakeclock.Now().In(berlin)
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Schedule: &autoscalingxv1.Schedule{
+							Cron:     fmt.Sprintf("%d %d * * *", now.Minute(), now.Hour()),
+							TimeZone: "Europe/Berlin",
+						},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+		})
+
+		It("should not update minReplicas if a recurring override is not active", func() {
+			By("creating a recurring override that only occurs on new year's day")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Schedule:    &autoscalingxv1.Schedule{Cron: "0 0 1 1 *"},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is not updated")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, consistentlyTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should reject an override with both a time and a schedule", func() {
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now()},
+						Schedule:    &autoscalingxv1.Schedule{Cron: "0 * * * *"},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).NotTo(Succeed())
+		})
+
+		It("should restore the original minReplicas when the HorizontalPodAutoscalerX is deleted", func() {
+			By("creating an override that is active")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+
+			By("deleting the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{ObjectMeta: metav1.ObjectMeta{Name: hpaxName, Namespace: namespace}}
+			Expect(k8sClient.Delete(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is restored")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(int32(1))))
+				g.Expect(hpa.Annotations).NotTo(HaveKey(OriginalMinReplicasAnnotation))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should set the release minReplicas when the HorizontalPodAutoscalerX is deleted", func() {
+			By("setting the release minReplicas")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.ReleaseMinReplicas = ptr.To(int32(5))
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("deleting the HorizontalPodAutoscalerX")
+			Expect(k8sClient.Delete(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is set to the release minReplicas")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(int32(5)))
+		})
+
+		It("should update maxReplicas to the base maxReplicas and cap minReplicas at it", func() {
+			By("setting the base maxReplicas below the fallback minReplicas")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MaxReplicas = ptr.To(fallbackMinReplicas - 5)
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false for longer than fallback duration")
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is capped at maxReplicas")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(fallbackMinReplicas - 5))
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(fallbackMinReplicas - 5)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should update maxReplicas to the highest maxReplicas of the active overrides", func() {
+			By("creating overrides that are active")
+			for i, maxReplicas := range []int32{200, 300} {
+				hpaOverride := &autoscalingxv1.HPAOverride{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("some-override-%d", i), Namespace: namespace},
+					Spec: autoscalingxv1.HPAOverrideSpec{
+						OverrideSpec: autoscalingxv1.OverrideSpec{
+							MinReplicas: fallbackMinReplicas + 10,
+							MaxReplicas: ptr.To(maxReplicas),
+							Duration:    metav1.Duration{Duration: 2 * time.Hour},
+							Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+						},
+						HPATargetName: hpaName,
+					},
+				}
+				Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+			}
+
+			By("getting the hpa to check if maxReplicas is updated")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(300)))
+				g.Expect(hpa.Annotations).To(HaveKeyWithValue(OriginalMaxReplicasAnnotation, fmt.Sprint(defaultHpa.Spec.MaxReplicas)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("deleting the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{ObjectMeta: metav1.ObjectMeta{Name: hpaxName, Namespace: namespace}}
+			Expect(k8sClient.Delete(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check if maxReplicas is restored")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(defaultHpa.Spec.MaxReplicas))
+				g.Expect(hpa.Annotations).NotTo(HaveKey(OriginalMaxReplicasAnnotation))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should keep a maxReplicas changed after the override that raised it expires", func() {
+			By("creating an override that raises maxReplicas")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						MaxReplicas: ptr.To(int32(200)),
+						Duration:    metav1.Duration{Duration: 2 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now()},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if maxReplicas is raised")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(200)))
+				g.Expect(hpa.Annotations).To(HaveKeyWithValue(OriginalMaxReplicasAnnotation, fmt.Sprint(defaultHpa.Spec.MaxReplicas)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("advancing the clock to the end of the override")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the hpa to check if maxReplicas is restored")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(defaultHpa.Spec.MaxReplicas))
+				g.Expect(hpa.Annotations).NotTo(HaveKey(OriginalMaxReplicasAnnotation))
+			}, requeueTimeout, interval).Should(Succeed())
+
+			By("changing the hpa maxReplicas")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			hpa.Spec.MaxReplicas = 150
+			Expect(k8sClient.Patch(ctx, hpa, client.MergeFrom(origHpa))).To(Succeed())
+
+			By("updating the HorizontalPodAutoscalerX to reconcile it")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MinReplicas = minReplicas + 1
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check if the changed maxReplicas is kept")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(minReplicas + 1)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(150)))
+			}, consistentlyTimeout, interval).Should(Succeed())
+		})
+
+		It("should update minReplicas if an override selects the HorizontalPodAutoscalerX by label", func() {
+			By("labelling the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Labels = map[string]string{"event": "black-friday"}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("creating an override that is active and selects the HorizontalPodAutoscalerX")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"event": "black-friday"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+
+			By("removing the label from the HorizontalPodAutoscalerX")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Labels = nil
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is reverted")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should reject an override with both a hpaTargetName and a selector", func() {
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+					Selector:      &metav1.LabelSelector{MatchLabels: map[string]string{"event": "black-friday"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).NotTo(Succeed())
+		})
+
+		It("should update minReplicas if a cluster override selects the HorizontalPodAutoscalerX", func() {
+			By("labelling the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Labels = map[string]string{"event": "black-friday"}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("creating a namespaced override that is active")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("creating a cluster override that is active with a higher minReplicas")
+			clusterHPAOverride := &autoscalingxv1.ClusterHPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-override"},
+				Spec: autoscalingxv1.ClusterHPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 20,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+					},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"event": "black-friday"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterHPAOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 20))
+
+			By("deleting the cluster override")
+			Expect(k8sClient.Delete(ctx, clusterHPAOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas falls back to the namespaced override")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas + 10))
+		})
+
+		It("should not update minReplicas if a cluster override selects another namespace", func() {
+			By("creating a cluster override that is active for another namespace")
+			clusterHPAOverride := &autoscalingxv1.ClusterHPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-cluster-override"},
+				Spec: autoscalingxv1.ClusterHPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 20,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: "some-other-namespace"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, clusterHPAOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is not updated")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, consistentlyTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should update minReplicas relative to the current replicas without compounding", func() {
+			By("updating the hpa status to have current replicas")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			hpa.Status.CurrentReplicas = 4
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("creating a relative override that is active")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						RelativeMinReplicas: &autoscalingxv1.RelativeMinReplicas{
+							Reference: autoscalingxv1.RelativeReferenceCurrentReplicas,
+							Percent:   200,
+						},
+						Duration: metav1.Duration{Duration: 2 * time.Hour},
+						Time:     metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(int32(8)))
+
+			By("updating the hpa status as it scales up to the new minReplicas")
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa = hpa.DeepCopy()
+			hpa.Status.CurrentReplicas = 8
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now()},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is not compounded")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, consistentlyTimeout, interval).Should(Equal(int32(8)))
+		})
+
+		It("should update minReplicas if a fallback trigger matches for longer than its duration", func() {
+			By("setting a fallback trigger on a reason of the AbleToScale condition")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.Fallback.Triggers = []autoscalingxv1.FallbackTrigger{
+				{
+					Type:     autoscalingv2.AbleToScale,
+					Status:   corev1.ConditionFalse,
+					Reason:   "FailedGetScale",
+					Duration: metav1.Duration{Duration: fallbackDuration},
+				},
+			}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false but able to scale as true")
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)},
+				},
+				{
+					Type:               autoscalingv2.AbleToScale,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is not updated")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, consistentlyTimeout, interval).Should(Equal(minReplicas))
+
+			By("updating the hpa status to have able to scale as false with the trigger reason")
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa = hpa.DeepCopy()
+			hpa.Status.Conditions[1] = autoscalingv2.HorizontalPodAutoscalerCondition{
+				Type:               autoscalingv2.AbleToScale,
+				Status:             corev1.ConditionFalse,
+				Reason:             "FailedGetScale",
+				LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				if hpa.Spec.MinReplicas != nil {
+					return *hpa.Spec.MinReplicas
+				}
+				return -1
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas))
+
+			By("getting the HorizontalPodAutoscalerX to check which trigger fired")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.FallbackTrigger).NotTo(BeNil())
+				g.Expect(hpax.Status.FallbackTrigger.Type).To(Equal(autoscalingv2.AbleToScale))
+				g.Expect(hpax.Status.FallbackTrigger.Reason).To(Equal("FailedGetScale"))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should export metrics for the fallback", func() {
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false for longer than fallback duration")
+			origHpa := hpa.DeepCopy()
+			inactiveSince := fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: inactiveSince},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("checking the metrics")
+			Eventually(func(g Gomega) {
+				g.Expect(testutil.ToFloat64(minReplicasGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(fallbackMinReplicas)))
+				g.Expect(testutil.ToFloat64(baseMinReplicasGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(minReplicas)))
+				g.Expect(testutil.ToFloat64(fallbackMinReplicasGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(fallbackMinReplicas)))
+				g.Expect(testutil.ToFloat64(fallbackEngagedGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(1)))
+				g.Expect(testutil.ToFloat64(scalingInactiveSinceGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(inactiveSince.Unix())))
+				g.Expect(testutil.ToFloat64(fallbackActivationsCounter.WithLabelValues(namespace, hpaxName))).To(Equal(float64(1)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("deleting the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{ObjectMeta: metav1.ObjectMeta{Name: hpaxName, Namespace: namespace}}
+			Expect(k8sClient.Delete(ctx, hpax)).To(Succeed())
+
+			By("checking the metrics are deleted")
+			Eventually(func() int {
+				return testutil.CollectAndCount(minReplicasGauge)
+			}, eventuallyTimeout, interval).Should(Equal(0))
+		})
+
+		It("should select the override by the override strategy and let it lower the base minReplicas", func() {
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}
+
+			By("raising the base minReplicas and setting the Min override strategy")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MinReplicas = 5
+			hpax.Spec.Fallback.MinReplicas = 5
+			hpax.Spec.OverrideStrategy = autoscalingxv1.OverrideStrategyMin
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(5)))
+
+			By("creating a low-priority override with a low minReplicas and a high-priority override with a higher one")
+			for _, spec := range []struct {
+				name        string
+				minReplicas int32
+				priority    int32
+			}{{"nights", 2, 0}, {"weekends", 3, 10}} {
+				hpaOverride := &autoscalingxv1.HPAOverride{
+					ObjectMeta: metav1.ObjectMeta{Name: spec.name, Namespace: namespace},
+					Spec: autoscalingxv1.HPAOverrideSpec{
+						OverrideSpec: autoscalingxv1.OverrideSpec{
+							MinReplicas: spec.minReplicas,
+							Priority:    spec.priority,
+							Duration:    metav1.Duration{Duration: 1 * time.Hour},
+							Time:        metav1.Time{Time: fakeclock.Now().Add(-30 * time.Minute)},
+						},
+						HPATargetName: hpaName,
+					},
+				}
+				Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+			}
+
+			By("getting the hpa to check the lowest override is applied below the base minReplicas")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(2)))
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.Winner).To(Equal(&autoscalingxv1.MinReplicasCandidate{
+					Source:      autoscalingxv1.CandidateSourceHPAOverride,
+					Name:        "nights",
+					MinReplicas: 2,
+				}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("setting the HighestPriority override strategy")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.OverrideStrategy = autoscalingxv1.OverrideStrategyHighestPriority
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check the highest priority override is applied")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(3)))
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "weekends", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: true}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should let a ScaleDown override lower the minReplicas down to the floor unless the fallback is engaged", func() {
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}
+
+			By("raising the base minReplicas and setting a floor")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MinReplicas = 5
+			hpax.Spec.FloorMinReplicas = ptr.To(int32(2))
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(5)))
+
+			By("creating a ScaleDown override to zero")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "quiet-hours", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						Type:     autoscalingxv1.OverrideTypeScaleDown,
+						Duration: metav1.Duration{Duration: 1 * time.Hour},
+						Time:     metav1.Time{Time: fakeclock.Now().Add(-30 * time.Minute)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the minReplicas is lowered to the floor")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(2)))
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.Winner).To(Equal(&autoscalingxv1.MinReplicasCandidate{
+					Source:      autoscalingxv1.CandidateSourceFloorMinReplicas,
+					MinReplicas: 2,
+				}))
+				g.Expect(hpax.Status.Candidates).To(ContainElement(autoscalingxv1.MinReplicasCandidate{
+					Source: autoscalingxv1.CandidateSourceHPAOverride,
+					Name:   "quiet-hours",
+				}))
+
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "quiet-hours", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: false}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("updating the hpa status with a failing condition for longer than the fallback duration")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-(fallbackDuration + time.Second))},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the hpa to check the fallback wins over the ScaleDown override")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas))
+		})
+
+		It("should raise the minReplicas of a ScaleDown override to zero to 1 unless scaling to zero is enabled", func() {
+			By("raising the base minReplicas")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MinReplicas = 5
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(int32(5))))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("creating a ScaleDown override to zero")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "quiet-hours", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						Type:     autoscalingxv1.OverrideTypeScaleDown,
+						Duration: metav1.Duration{Duration: 1 * time.Hour},
+						Time:     metav1.Time{Time: fakeclock.Now().Add(-30 * time.Minute)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpax to check the minReplicas is raised to 1")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.Winner).To(Equal(&autoscalingxv1.MinReplicasCandidate{
+					Source: autoscalingxv1.CandidateSourceHPAOverride,
+					Name:   "quiet-hours",
+				}))
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionReady)).To(HaveField("Reason", "HPAUpdated"))
+
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(int32(1))))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should ramp the minReplicas of an override up before it starts and down after it ends", func() {
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}
+
+			By("creating an override that warms up in 3 steps and cools down in 2")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "launch", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: 7,
+						Duration:    metav1.Duration{Duration: 2 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(3 * time.Second)},
+						WarmUp:      &autoscalingxv1.Ramp{Duration: metav1.Duration{Duration: 3 * time.Second}, Steps: ptr.To(int32(3))},
+						CoolDown:    &autoscalingxv1.Ramp{Duration: metav1.Duration{Duration: 2 * time.Second}, Steps: ptr.To(int32(2))},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the minReplicas steps up to the override before it starts")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(3)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(5)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(7)))
+
+			By("advancing the clock to the end of the override")
+			fakeclock.Step(3 * time.Second)
+			Consistently(getMinReplicas, consistentlyTimeout, interval).Should(Equal(int32(7)))
+
+			By("getting the hpa to check the minReplicas steps down to the base minReplicas after it ends")
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(4)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should ramp the minReplicas of an override without steps in at most 10 steps", func() {
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}
+
+			By("creating an override to 41 replicas that warms up over 10 seconds")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "launch", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: 41,
+						Duration:    metav1.Duration{Duration: time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(10 * time.Second)},
+						WarmUp:      &autoscalingxv1.Ramp{Duration: metav1.Duration{Duration: 10 * time.Second}},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the minReplicas steps up by 4 replicas a second")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(5)))
+			fakeclock.Step(time.Second)
+			Eventually(getMinReplicas, requeueTimeout, interval).Should(Equal(int32(9)))
+		})
+
+		It("should apply an override ahead of its start by its lead time and report whether it was ready on time", func() {
+			getMinReplicas := func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}
+
+			By("creating the scale target with 3 ready replicas")
+			labels := map[string]string{"app": "myapp"}
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultHpa.Spec.ScaleTargetRef.Name, Namespace: namespace},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app"}}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, deployment)
+			deployment.Status.Replicas = 3
+			deployment.Status.ReadyReplicas = 3
+			Expect(k8sClient.Status().Update(ctx, deployment)).To(Succeed())
+
+			By("creating a pod of the scale target that took 2s to become ready")
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: namespace, Labels: labels},
+				Spec:       deployment.Spec.Template.Spec,
+			}
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pod))).To(Succeed())
+			})
+			pod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: pod.CreationTimestamp.Add(2 * time.Second)},
+			}}
+			Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+			By("creating a pod of the scale target whose readiness flapped an hour after its creation")
+			flappedPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "myapp-flapped", Namespace: namespace, Labels: labels},
+				Spec:       deployment.Spec.Template.Spec,
+			}
+			Expect(k8sClient.Create(ctx, flappedPod)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, flappedPod)
+			flappedPod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: flappedPod.CreationTimestamp.Add(time.Hour)},
+			}}
+			Expect(k8sClient.Status().Update(ctx, flappedPod)).To(Succeed())
+
+			By("creating an override with a lead time of 1s plus the startup latency")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "prewarm", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: 5,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(3 * time.Second)},
+						LeadTime:    &autoscalingxv1.LeadTime{Duration: metav1.Duration{Duration: time.Second}, FromStartupLatency: true},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the override is applied before it starts")
+			Eventually(getMinReplicas, eventuallyTimeout, interval).Should(Equal(int32(5)))
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.StartupLatency).To(Equal(&metav1.Duration{Duration: 2 * time.Second}))
+				g.Expect(hpax.Status.OverrideReadiness).To(BeEmpty())
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("replacing the pod with one that took 1s to become ready")
+			Expect(k8sClient.Delete(ctx, pod)).To(Succeed())
+			fasterPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "myapp-faster", Namespace: namespace, Labels: labels},
+				Spec:       deployment.Spec.Template.Spec,
+			}
+			Expect(k8sClient.Create(ctx, fasterPod)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, fasterPod)
+			fasterPod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: fasterPod.CreationTimestamp.Add(time.Second)},
+			}}
+			Expect(k8sClient.Status().Update(ctx, fasterPod)).To(Succeed())
+
+			By("updating the HorizontalPodAutoscalerX to reconcile it")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.MinReplicas = minReplicas + 1
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the HorizontalPodAutoscalerX to check the highest startup latency is kept")
+			Consistently(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.StartupLatency).To(Equal(&metav1.Duration{Duration: 2 * time.Second}))
+			}, consistentlyTimeout, interval).Should(Succeed())
+			Expect(getMinReplicas()).To(Equal(int32(5)))
+
+			By("advancing the clock to the start of the override")
+			fakeclock.Step(3 * time.Second)
+
+			By("getting the HorizontalPodAutoscalerX to check the scale target is reported as not ready on time")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.OverrideReadiness).To(ConsistOf(And(
+					HaveField("Name", namespace+"/prewarm"),
+					HaveField("MinReplicas", int32(5)),
+					HaveField("ReadyReplicas", int32(3)),
+					HaveField("Ready", false),
+				)))
+			}, requeueTimeout, interval).Should(Succeed())
+		})
+
+		It("should create and own the hpa from the hpaTemplate", func() {
+			By("creating a HorizontalPodAutoscalerX with an hpaTemplate")
+			templatedHpax := &autoscalingxv1.HorizontalPodAutoscalerX{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpax", Namespace: namespace},
+				Spec: autoscalingxv1.HorizontalPodAutoscalerXSpec{
+					HPATargetName: "templated-hpa",
+					MinReplicas:   minReplicas + 1,
+					HPATemplate: &autoscalingxv1.HPATemplate{
+						ScaleTargetRef: defaultHpa.Spec.ScaleTargetRef,
+						MaxReplicas:    20,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the hpa to check that it is created and owned")
+			templatedHpaNamespacedName := types.NamespacedName{Name: "templated-hpa", Namespace: namespace}
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.ScaleTargetRef).To(Equal(defaultHpa.Spec.ScaleTargetRef))
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(minReplicas + 1)))
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(20)))
+				g.Expect(metav1.IsControlledBy(hpa, templatedHpax)).To(BeTrue())
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("updating the maxReplicas of the hpaTemplate")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+			templatedHpax.Spec.HPATemplate.MaxReplicas = 30
+			Expect(k8sClient.Update(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the hpa to check that its maxReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				return hpa.Spec.MaxReplicas
+			}, eventuallyTimeout, interval).Should(Equal(int32(30)))
+
+			By("deleting the HorizontalPodAutoscalerX and the hpa")
+			Expect(k8sClient.Delete(ctx, templatedHpax)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax))
+			}, eventuallyTimeout, interval).Should(BeTrue())
+			// envtest doesn't run the garbage collector, so the owned hpa has to be deleted explicitly.
+			Expect(k8sClient.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpa", Namespace: namespace},
+			})).To(Succeed())
+		})
+
+		It("should not update the hpa from an hpaTemplate whose fields the API server defaults", func() {
+			By("creating a HorizontalPodAutoscalerX with an hpaTemplate without metrics and with a partial behavior")
+			templatedHpax := &autoscalingxv1.HorizontalPodAutoscalerX{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpax", Namespace: namespace},
+				Spec: autoscalingxv1.HorizontalPodAutoscalerXSpec{
+					HPATargetName: "templated-hpa",
+					MinReplicas:   minReplicas,
+					HPATemplate: &autoscalingxv1.HPATemplate{
+						ScaleTargetRef: defaultHpa.Spec.ScaleTargetRef,
+						Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+							ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To(int32(60))},
+						},
+						MaxReplicas: 20,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the hpa to check that it is created with the defaulted metrics and behavior")
+			templatedHpaNamespacedName := types.NamespacedName{Name: "templated-hpa", Namespace: namespace}
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.Metrics).NotTo(BeEmpty())
+				g.Expect(hpa.Spec.Behavior.ScaleDown.Policies).NotTo(BeEmpty())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(minReplicas)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("updating the HorizontalPodAutoscalerX to reconcile it")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+			templatedHpax.Spec.MinReplicas = minReplicas + 1
+			Expect(k8sClient.Update(ctx, templatedHpax)).To(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(minReplicas + 1)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("labelling the HorizontalPodAutoscalerX to reconcile it without changing the hpa")
+			resourceVersion := hpa.ResourceVersion
+			skippedTemplateWrites := testutil.ToFloat64(writesCounter.WithLabelValues(namespace, templatedHpax.Name, "hpa_template", "skipped"))
+			performedTemplateWrites := testutil.ToFloat64(writesCounter.WithLabelValues(namespace, templatedHpax.Name, "hpa_template", "performed"))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+			templatedHpax.Labels = map[string]string{"reconcile": "again"}
+			Expect(k8sClient.Update(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the hpa to check that it is not updated")
+			Consistently(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.ResourceVersion).To(Equal(resourceVersion))
+			}, consistentlyTimeout, interval).Should(Succeed())
+			Expect(testutil.ToFloat64(writesCounter.WithLabelValues(namespace, templatedHpax.Name, "hpa_template", "skipped"))).To(BeNumerically(">", skippedTemplateWrites))
+			Expect(testutil.ToFloat64(writesCounter.WithLabelValues(namespace, templatedHpax.Name, "hpa_template", "performed"))).To(Equal(performedTemplateWrites))
+
+			By("deleting the HorizontalPodAutoscalerX and the hpa")
+			Expect(k8sClient.Delete(ctx, templatedHpax)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax))
+			}, eventuallyTimeout, interval).Should(BeTrue())
+			// envtest doesn't run the garbage collector, so the owned hpa has to be deleted explicitly.
+			Expect(k8sClient.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpa", Namespace: namespace},
+			})).To(Succeed())
+		})
+
+		It("should record the minReplicas candidates and the winner in the status", func() {
+			By("getting the HorizontalPodAutoscalerX to check the base minReplicas wins")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.MinReplicas).To(Equal(ptr.To(minReplicas)))
+				g.Expect(hpax.Status.Winner).To(Equal(&autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceBaseMinReplicas, MinReplicas: minReplicas}))
+				g.Expect(hpax.Status.LastAppliedTime).NotTo(BeNil())
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("creating two active overrides")
+			for i, name := range []string{"some-override", "other-override"} {
+				hpaOverride := &autoscalingxv1.HPAOverride{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+					Spec: autoscalingxv1.HPAOverrideSpec{
+						OverrideSpec: autoscalingxv1.OverrideSpec{
+							MinReplicas: fallbackMinReplicas + int32(i),
+							Duration:    metav1.Duration{Duration: 1 * time.Hour},
+							Time:        metav1.Time{Time: fakeclock.Now().Add(-30 * time.Minute)},
+						},
+						HPATargetName: hpaName,
+					},
+				}
+				Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+			}
+
+			By("getting the HorizontalPodAutoscalerX to check the highest override wins")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.MinReplicas).To(Equal(ptr.To(fallbackMinReplicas + 1)))
+				g.Expect(hpax.Status.Candidates).To(ConsistOf(
+					autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceBaseMinReplicas, MinReplicas: minReplicas},
+					autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceHPAOverride, Name: "some-override", MinReplicas: fallbackMinReplicas},
+					autoscalingxv1.MinReplicasCandidate{Source: autoscalingxv1.CandidateSourceHPAOverride, Name: "other-override", MinReplicas: fallbackMinReplicas + 1},
+				))
+				g.Expect(hpax.Status.Winner).To(Equal(&autoscalingxv1.MinReplicasCandidate{
+					Source:      autoscalingxv1.CandidateSourceHPAOverride,
+					Name:        "other-override",
+					MinReplicas: fallbackMinReplicas + 1,
+				}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should apply the hpa replicas as its field manager and skip no-op writes", func() {
+			By("getting the hpa to check the replicas are applied by the field manager")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.ManagedFields).To(ContainElement(And(
+					HaveField("Manager", FieldManager),
+					HaveField("Operation", metav1.ManagedFieldsOperationApply),
+					HaveField("FieldsV1.Raw", ContainSubstring(`"f:minReplicas"`)),
+				)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("triggering a reconcile that doesn't change the replicas")
+			skippedHpaWrites := testutil.ToFloat64(writesCounter.WithLabelValues(namespace, hpaxName, "hpa", "skipped"))
+			performedHpaWrites := testutil.ToFloat64(writesCounter.WithLabelValues(namespace, hpaxName, "hpa", "performed"))
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			metav1.SetMetaDataAnnotation(&hpax.ObjectMeta, "some-annotation", "some-value")
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check that it is not written")
+			Consistently(func() string {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return hpa.ResourceVersion
+			}, consistentlyTimeout, interval).Should(Equal(hpa.ResourceVersion))
+
+			By("checking the metrics count the write as skipped")
+			Expect(testutil.ToFloat64(writesCounter.WithLabelValues(namespace, hpaxName, "hpa", "skipped"))).To(BeNumerically(">", skippedHpaWrites))
+			Expect(testutil.ToFloat64(writesCounter.WithLabelValues(namespace, hpaxName, "hpa", "performed"))).To(Equal(performedHpaWrites))
+		})
+
+		It("should take ownership of minReplicas from another field manager with the Force conflict policy", func() {
+			By("updating the hpa minReplicas as another field manager")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			hpa.Spec.MinReplicas = ptr.To(minReplicas + 4)
+			Expect(k8sClient.Patch(ctx, hpa, client.MergeFrom(origHpa), client.FieldOwner("someone-else"))).To(Succeed())
+
+			By("listing the events to check the conflict is reported")
+			Eventually(func(g Gomega) {
+				events := &corev1.EventList{}
+				g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace))).To(Succeed())
+				g.Expect(events.Items).To(ContainElement(And(
+					HaveField("InvolvedObject.Name", hpaxName),
+					HaveField("Reason", "Conflict"),
+					HaveField("Message", ContainSubstring("took ownership of the hpa spec.minReplicas and spec.maxReplicas from someone-else")),
+				)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("getting the hpa to check if minReplicas is reverted")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}, eventuallyTimeout, interval).Should(Equal(minReplicas))
+		})
+
+		It("should back off from another field manager with the BackOff conflict policy", func() {
+			By("setting the conflict policy to BackOff")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.ConflictPolicy = autoscalingxv1.ConflictPolicyBackOff
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("updating the hpa minReplicas as another field manager")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+			origHpa := hpa.DeepCopy()
+			hpa.Spec.MinReplicas = ptr.To(minReplicas + 4)
+			Expect(k8sClient.Patch(ctx, hpa, client.MergeFrom(origHpa), client.FieldOwner("someone-else"))).To(Succeed())
+
+			By("getting the HorizontalPodAutoscalerX to check the conflict is reported")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionConflict)).To(And(
+					HaveField("Status", corev1.ConditionTrue),
+					HaveField("Reason", "BackedOff"),
+					HaveField("Message", ContainSubstring("someone-else")),
+				))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("getting the hpa to check that minReplicas is left to the other field manager")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}, consistentlyTimeout, interval).Should(Equal(minReplicas + 4))
+		})
+
+		It("should not back off from the maxReplicas the hpaTemplate applied with the BackOff conflict policy", func() {
+			By("creating a HorizontalPodAutoscalerX with an hpaTemplate and the BackOff conflict policy")
+			templatedHpax := &autoscalingxv1.HorizontalPodAutoscalerX{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpax", Namespace: namespace},
+				Spec: autoscalingxv1.HorizontalPodAutoscalerXSpec{
+					HPATargetName:  "templated-hpa",
+					MinReplicas:    minReplicas,
+					ConflictPolicy: autoscalingxv1.ConflictPolicyBackOff,
+					HPATemplate: &autoscalingxv1.HPATemplate{
+						ScaleTargetRef: defaultHpa.Spec.ScaleTargetRef,
+						MaxReplicas:    20,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, templatedHpax)).To(Succeed())
+			templatedHpaNamespacedName := types.NamespacedName{Name: "templated-hpa", Namespace: namespace}
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(20)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("creating an override that raises the maxReplicas")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: 30,
+						MaxReplicas: ptr.To(int32(50)),
+						Duration:    metav1.Duration{Duration: time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now()},
+					},
+					HPATargetName: "templated-hpa",
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the override is applied without a conflict")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(int32(30))))
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(50)))
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+				g.Expect(getCondition(templatedHpax, autoscalingxv1.ConditionConflict)).To(HaveField("Status", corev1.ConditionFalse))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("deleting the HorizontalPodAutoscalerX and the hpa")
+			Expect(k8sClient.Delete(ctx, templatedHpax)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax))
+			}, eventuallyTimeout, interval).Should(BeTrue())
+			// envtest doesn't run the garbage collector, so the owned hpa has to be deleted explicitly.
+			Expect(k8sClient.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpa", Namespace: namespace},
+			})).To(Succeed())
+		})
+
+		It("should not back off from the maxReplicas it restored with the BackOff conflict policy", func() {
+			By("creating an override that raises the maxReplicas")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						MaxReplicas: ptr.To(int32(200)),
+						Duration:    metav1.Duration{Duration: 2 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now()},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(200)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("setting the conflict policy to BackOff")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.ConflictPolicy = autoscalingxv1.ConflictPolicyBackOff
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("advancing the clock to the end of the override to restore the maxReplicas")
+			fakeclock.Step(2 * time.Second)
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(defaultHpa.Spec.MaxReplicas))
+				g.Expect(hpa.Annotations).NotTo(HaveKey(OriginalMaxReplicasAnnotation))
+			}, requeueTimeout, interval).Should(Succeed())
+
+			By("creating another override that raises the maxReplicas")
+			hpaOverride = &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "another-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						MaxReplicas: ptr.To(int32(250)),
+						Duration:    metav1.Duration{Duration: time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now()},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the hpa to check the override is applied without a conflict")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(250)))
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionConflict)).To(HaveField("Status", corev1.ConditionFalse))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should not update the hpa in DryRun mode but record what it would update it to", func() {
+			By("setting the HorizontalPodAutoscalerX to DryRun mode")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.Mode = autoscalingxv1.ModeDryRun
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+			Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+
+			By("updating the hpa status to have scaling active condition as false for longer than fallback duration")
+			origHpa := hpa.DeepCopy()
+			hpa.Status.Conditions = []autoscalingv2.HorizontalPodAutoscalerCondition{
+				{
+					Type:               autoscalingv2.ScalingActive,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: fakeclock.Now().Add(-fallbackDuration).Add(-1 * time.Second)},
+				},
+			}
+			Expect(k8sClient.Status().Patch(ctx, hpa, client.MergeFrom(origHpa))).Should(Succeed())
+
+			By("getting the HorizontalPodAutoscalerX to check the dry run status")
+			Eventually(func(g Gomega) {
+				hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+				g.Expect(hpax.Status.DryRun).To(Equal(&autoscalingxv1.DryRunStatus{
+					MinReplicas: fallbackMinReplicas,
+					MaxReplicas: 110,
+					Reason:      "Fallback",
+				}))
+				g.Expect(getCondition(hpax, autoscalingxv1.ConditionReady)).To(HaveField("Reason", "DryRun"))
+			}, eventuallyTimeout, interval).Should(Succeed())
+			Expect(testutil.ToFloat64(dryRunGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(minReplicasGauge.WithLabelValues(namespace, hpaxName))).To(Equal(float64(fallbackMinReplicas)))
+
+			By("getting the hpa to check that minReplicas is not updated")
+			Consistently(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}, consistentlyTimeout, interval).Should(Equal(minReplicas))
+
+			By("setting the HorizontalPodAutoscalerX back to Enforce mode")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.Mode = autoscalingxv1.ModeEnforce
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the hpa to check that minReplicas is updated")
+			Eventually(func() int32 {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				Expect(k8sClient.Get(ctx, hpaNamespacedName, hpa)).To(Succeed())
+				return ptr.Deref(hpa.Spec.MinReplicas, -1)
+			}, eventuallyTimeout, interval).Should(Equal(fallbackMinReplicas))
+		})
+
+		It("should not create the hpa from the hpaTemplate in DryRun mode but record that it would", func() {
+			By("creating a HorizontalPodAutoscalerX with an hpaTemplate in DryRun mode")
+			templatedHpax := &autoscalingxv1.HorizontalPodAutoscalerX{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpax", Namespace: namespace},
+				Spec: autoscalingxv1.HorizontalPodAutoscalerXSpec{
+					HPATargetName: "templated-hpa",
+					MinReplicas:   minReplicas + 1,
+					Mode:          autoscalingxv1.ModeDryRun,
+					HPATemplate: &autoscalingxv1.HPATemplate{
+						ScaleTargetRef: defaultHpa.Spec.ScaleTargetRef,
+						MaxReplicas:    20,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the HorizontalPodAutoscalerX to check the dry run status")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+				g.Expect(templatedHpax.Status.DryRun).To(Equal(&autoscalingxv1.DryRunStatus{
+					MinReplicas: minReplicas + 1,
+					MaxReplicas: 20,
+					Reason:      string(autoscalingxv1.CandidateSourceBaseMinReplicas),
+					HPATemplate: "Create",
+				}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("getting the hpa to check that it is not created")
+			templatedHpaNamespacedName := types.NamespacedName{Name: "templated-hpa", Namespace: namespace}
+			Consistently(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, templatedHpaNamespacedName, &autoscalingv2.HorizontalPodAutoscaler{}))
+			}, consistentlyTimeout, interval).Should(BeTrue())
+
+			By("setting the HorizontalPodAutoscalerX back to Enforce mode")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax)).To(Succeed())
+			templatedHpax.Spec.Mode = autoscalingxv1.ModeEnforce
+			Expect(k8sClient.Update(ctx, templatedHpax)).To(Succeed())
+
+			By("getting the hpa to check that it is created")
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, templatedHpaNamespacedName, hpa)).To(Succeed())
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To(minReplicas + 1)))
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(20)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("deleting the HorizontalPodAutoscalerX and the hpa")
+			Expect(k8sClient.Delete(ctx, templatedHpax)).To(Succeed())
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(templatedHpax), templatedHpax))
+			}, eventuallyTimeout, interval).Should(BeTrue())
+			// envtest doesn't run the garbage collector, so the owned hpa has to be deleted explicitly.
+			Expect(k8sClient.Delete(ctx, &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "templated-hpa", Namespace: namespace},
+			})).To(Succeed())
+		})
 	})
 })
@@ -0,0 +1,521 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+	custompredicate "rrethy.io/horizontalpodautoscalerx/internal/predicate"
+)
+
+const (
+	HPAOverrideControllerName = "hpaoverride"
+
+	// defaultRampSteps is the most steps a ramp that doesn't set its steps takes, so that a large ramp doesn't update
+	// the HPA for every replica.
+	defaultRampSteps = 10
+)
+
+// HPAOverrideReconciler reconciles the status of a HPAOverride object
+type HPAOverrideReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+	Clock         clock.Clock
+
+	// DefaultTTLAfterExpiry is the ttlAfterExpiry of the HPAOverride objects that don't set one. Expired HPAOverride
+	// objects are kept if it is 0.
+	DefaultTTLAfterExpiry time.Duration
+}
+
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=hpaoverrides,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=hpaoverrides/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=horizontalpodautoscalerxes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=autoscalingx.rrethy.io,resources=clusterhpaoverrides,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile keeps the HPAOverride status in sync with its lifecycle and the
+// HorizontalPodAutoscalerX objects it affects.
+func (r *HPAOverrideReconciler) Reconcile(ctx context.Context, hpaOverride *autoscalingxv1.HPAOverride) (res ctrl.Result, retErr error) {
+	if !hpaOverride.DeletionTimestamp.IsZero() {
+		// The object is being deleted, don't do anything.
+		return ctrl.Result{}, nil
+	}
+
+	log := log.FromContext(ctx)
+	orig := hpaOverride.DeepCopy()
+	defer func() {
+		if !apiequality.Semantic.DeepEqual(orig, hpaOverride) {
+			if err := r.Status().Patch(ctx, hpaOverride, client.MergeFrom(orig)); err != nil {
+				log.Error(err, "updating status")
+			}
+		}
+	}()
+
+	hpaxs, err := listHPAXForHPAOverride(ctx, r, hpaOverride)
+	if err != nil {
+		log.Error(err, "listing targets")
+		return ctrl.Result{}, err
+	}
+
+	now := r.Clock.Now()
+	led := leadOverride(hpaOverride, hpaxs)
+	phase, nextTransition, err := overridePhase(&led, now)
+	if err != nil {
+		log.Error(err, "evaluating override window")
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	}
+	start, end, _ := overrideWindow(hpaOverride, now)
+	if ttl, ok := r.ttlAfterExpiry(hpaOverride); ok && phase == autoscalingxv1.HPAOverridePhaseExpired && !end.IsZero() {
+		deleteTime := end.Add(rampDuration(hpaOverride.Spec.CoolDown) + ttl)
+		if !now.Before(deleteTime) {
+			return ctrl.Result{}, r.deleteExpired(ctx, hpaOverride, hpaxs, ttl)
+		}
+		nextTransition = deleteTime
+	}
+	hpaOverride.Status.Phase = phase
+	hpaOverride.Status.Active = phase == autoscalingxv1.HPAOverridePhaseActive
+	hpaOverride.Status.StartTime = nil
+	hpaOverride.Status.EndTime = nil
+	if !start.IsZero() {
+		hpaOverride.Status.StartTime = &metav1.Time{Time: start}
+		hpaOverride.Status.EndTime = &metav1.Time{Time: end}
+	}
+
+	hpaOverride.Status.Targets = getTargets(hpaOverride, hpaxs)
+	hpaOverride.Status.ObservedGeneration = ptr.To(hpaOverride.Generation)
+
+	if !nextTransition.IsZero() {
+		res.RequeueAfter = nextTransition.Sub(now)
+	}
+	return res, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HPAOverrideReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(HPAOverrideControllerName).
+		For(
+			&autoscalingxv1.HPAOverride{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&autoscalingxv1.HorizontalPodAutoscalerX{},
+			handler.EnqueueRequestsFromMapFunc(r.findHPAOverridesForHPAX),
+			builder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.LabelChangedPredicate{},
+				custompredicate.HPAXOverrideStatusChangedPredicate{},
+			)),
+		).
+		Complete(reconcile.AsReconciler(mgr.GetClient(), r))
+}
+
+// getTargets returns the given HorizontalPodAutoscalerX objects targeted by the HPAOverride, and whether each of them
+// applies the minReplicas of the HPAOverride.
+func getTargets(hpaOverride *autoscalingxv1.HPAOverride, hpaxs []autoscalingxv1.HorizontalPodAutoscalerX) []autoscalingxv1.HPAOverrideTarget {
+	if len(hpaxs) == 0 {
+		return nil
+	}
+
+	targets := make([]autoscalingxv1.HPAOverrideTarget, 0, len(hpaxs))
+	for _, hpax := range hpaxs {
+		winner := hpax.Status.Winner
+		targets = append(targets, autoscalingxv1.HPAOverrideTarget{
+			Name:    hpax.Name,
+			Winning: winner != nil && winner.Source == autoscalingxv1.CandidateSourceHPAOverride && winner.Name == hpaOverride.Name,
+		})
+	}
+	return targets
+}
+
+// ttlAfterExpiry returns the ttlAfterExpiry of the HPAOverride, or otherwise the default one, and whether there is one.
+func (r *HPAOverrideReconciler) ttlAfterExpiry(hpaOverride *autoscalingxv1.HPAOverride) (time.Duration, bool) {
+	if hpaOverride.Spec.TTLAfterExpiry != nil {
+		return hpaOverride.Spec.TTLAfterExpiry.Duration, true
+	}
+	return r.DefaultTTLAfterExpiry, r.DefaultTTLAfterExpiry > 0
+}
+
+// deleteExpired deletes the expired HPAOverride, after emitting an event on each HorizontalPodAutoscalerX it targets.
+func (r *HPAOverrideReconciler) deleteExpired(
+	ctx context.Context,
+	hpaOverride *autoscalingxv1.HPAOverride,
+	hpaxs []autoscalingxv1.HorizontalPodAutoscalerX,
+	ttl time.Duration,
+) error {
+	for i := range hpaxs {
+		r.EventRecorder.Eventf(&hpaxs[i], corev1.EventTypeNormal, "DeletingExpiredOverride", "deleting override %s, which expired more than %s ago",
+			hpaOverride.Name, ttl)
+	}
+	if err := r.Delete(ctx, hpaOverride, client.Preconditions{UID: &hpaOverride.UID}); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting expired override: %w", err)
+	}
+	log.FromContext(ctx).Info("deleted expired override", "ttlAfterExpiry", ttl)
+	return nil
+}
+
+// findHPAOverridesForHPAX finds all HPAOverride objects that target the given HorizontalPodAutoscalerX.
+func (r *HPAOverrideReconciler) findHPAOverridesForHPAX(ctx context.Context, o client.Object) []reconcile.Request {
+	hpax, ok := o.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return nil
+	}
+
+	hpaOverrides, err := listHPAOverridesForHPAX(ctx, r, hpax)
+	if err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(hpaOverrides))
+	for _, hpaOverride := range hpaOverrides {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      hpaOverride.GetName(),
+				Namespace: hpaOverride.GetNamespace(),
+			},
+		})
+	}
+	return requests
+}
+
+// overrideWindow returns the [start, end) window of the HPAOverride that is active at the given time,
+// or the next window to start if none is active. A one-off override only has a single window, and a
+// recurring override whose schedule never fires has none, in which case both times are zero.
+func overrideWindow(hpaOverride *autoscalingxv1.HPAOverride, now time.Time) (time.Time, time.Time, error) {
+	duration := hpaOverride.Spec.Duration.Duration
+	if hpaOverride.Spec.Schedule == nil {
+		start := hpaOverride.Spec.Time.Time
+		return start, start.Add(duration), nil
+	}
+
+	schedule, loc, err := parseSchedule(hpaOverride.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// The first start after now-duration-coolDown is either the start of the
+	// window containing now, possibly cooling down, or the start of the next window.
+	start := schedule.Next(now.In(loc).Add(-duration - rampDuration(hpaOverride.Spec.CoolDown)))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, nil
+	}
+	return start, start.Add(duration), nil
+}
+
+// parseSchedule parses the cron expression of the Schedule and loads its time zone.
+func parseSchedule(schedule *autoscalingxv1.Schedule) (cron.Schedule, *time.Location, error) {
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading time zone %q: %w", schedule.TimeZone, err)
+	}
+
+	cronSchedule, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing cron expression %q: %w", schedule.Cron, err)
+	}
+	return cronSchedule, loc, nil
+}
+
+// overridePhase returns the phase of the HPAOverride at the given time, and the time at which
+// the phase next changes, or the zero time if it won't. The override is active during its lead time
+// and while it warms up and cools down.
+func overridePhase(hpaOverride *autoscalingxv1.HPAOverride, now time.Time) (autoscalingxv1.HPAOverridePhase, time.Time, error) {
+	start, end, err := overrideWindow(hpaOverride, now)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if !start.IsZero() {
+		start = start.Add(-leadTime(hpaOverride) - rampDuration(hpaOverride.Spec.WarmUp))
+		end = end.Add(rampDuration(hpaOverride.Spec.CoolDown))
+	}
+
+	switch {
+	case start.After(now):
+		return autoscalingxv1.HPAOverridePhasePending, start, nil
+	case end.After(now):
+		return autoscalingxv1.HPAOverridePhaseActive, end, nil
+	default:
+		return autoscalingxv1.HPAOverridePhaseExpired, time.Time{}, nil
+	}
+}
+
+// resolveOverrides returns the HPAOverride objects with the minReplicas of each active relative override resolved,
+// along with the resolved minReplicas to record in the status of the HorizontalPodAutoscalerX. A relative override
+// keeps the minReplicas recorded for its current window, otherwise it is resolved against the hpa, unless the hpa
+// is nil or the reference is still zero, in which case only the absolute minReplicas applies.
+func resolveOverrides(
+	hpax *autoscalingxv1.HorizontalPodAutoscalerX,
+	hpa *autoscalingv2.HorizontalPodAutoscaler,
+	hpaOverrides []autoscalingxv1.HPAOverride,
+	now time.Time,
+) ([]autoscalingxv1.HPAOverride, []autoscalingxv1.ResolvedOverride) {
+	resolved := make([]autoscalingxv1.HPAOverride, 0, len(hpaOverrides))
+	var resolvedOverrides []autoscalingxv1.ResolvedOverride
+	for _, hpaOverride := range hpaOverrides {
+		relative := hpaOverride.Spec.RelativeMinReplicas
+		if relative == nil {
+			resolved = append(resolved, hpaOverride)
+			continue
+		}
+		if phase, _, err := overridePhase(&hpaOverride, now); err != nil || phase != autoscalingxv1.HPAOverridePhaseActive {
+			resolved = append(resolved, hpaOverride)
+			continue
+		}
+
+		start, _, _ := overrideWindow(&hpaOverride, now)
+		key := overrideKey(&hpaOverride)
+		var resolvedOverride *autoscalingxv1.ResolvedOverride
+		for _, previous := range hpax.Status.ResolvedOverrides {
+			if previous.Name == key && previous.StartTime.Time.Equal(start) {
+				resolvedOverride = previous.DeepCopy()
+				break
+			}
+		}
+		if resolvedOverride == nil && hpa != nil {
+			// Resolving once per window keeps the override from compounding as the HPA scales up.
+			if reference := relativeReference(relative.Reference, hpax, hpa); reference > 0 {
+				maxReplicas := hpa.Spec.MaxReplicas
+				if hpaOverride.Spec.MaxReplicas != nil {
+					maxReplicas = *hpaOverride.Spec.MaxReplicas
+				}
+				resolvedOverride = &autoscalingxv1.ResolvedOverride{
+					Name:        key,
+					StartTime:   metav1.Time{Time: start},
+					MinReplicas: min(int32(math.Ceil(float64(reference)*float64(relative.Percent)/100)), maxReplicas),
+				}
+			}
+		}
+		if resolvedOverride != nil {
+			resolvedOverrides = append(resolvedOverrides, *resolvedOverride)
+			hpaOverride = *hpaOverride.DeepCopy()
+			hpaOverride.Spec.MinReplicas = max(hpaOverride.Spec.MinReplicas, resolvedOverride.MinReplicas)
+		}
+		resolved = append(resolved, hpaOverride)
+	}
+	return resolved, resolvedOverrides
+}
+
+// rampOverrides returns the HPAOverride objects with the minReplicas of each active override that is warming up or
+// cooling down ramped from the base minReplicas of the HorizontalPodAutoscalerX. It also returns the time of the next
+// step of any ramp, or the zero time if there is none.
+func rampOverrides(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpaOverrides []autoscalingxv1.HPAOverride, now time.Time) ([]autoscalingxv1.HPAOverride, time.Time) {
+	ramped := make([]autoscalingxv1.HPAOverride, 0, len(hpaOverrides))
+	var nextStep time.Time
+	for _, hpaOverride := range hpaOverrides {
+		if phase, _, err := overridePhase(&hpaOverride, now); err == nil && phase == autoscalingxv1.HPAOverridePhaseActive {
+			minReplicas, step := rampMinReplicas(&hpaOverride, hpax.Spec.MinReplicas, now)
+			if minReplicas != hpaOverride.Spec.MinReplicas {
+				hpaOverride = *hpaOverride.DeepCopy()
+				hpaOverride.Spec.MinReplicas = minReplicas
+			}
+			nextStep = earliest(nextStep, step)
+		}
+		ramped = append(ramped, hpaOverride)
+	}
+	return ramped, nextStep
+}
+
+// rampMinReplicas returns the minReplicas of the active HPAOverride, which is ramped between the base minReplicas and
+// its own minReplicas in equal steps while it warms up or cools down. A warm-up takes its first step when it starts and
+// ends at the lead time before the start of the override, and a cool-down takes its last step when it ends. It also
+// returns the time of the next step, or the zero time if there is none.
+func rampMinReplicas(hpaOverride *autoscalingxv1.HPAOverride, baseMinReplicas int32, now time.Time) (int32, time.Time) {
+	target := hpaOverride.Spec.MinReplicas
+	start, end, err := overrideWindow(hpaOverride, now)
+	if err != nil || start.IsZero() {
+		return target, time.Time{}
+	}
+	start = start.Add(-leadTime(hpaOverride))
+
+	var ramp *autoscalingxv1.Ramp
+	var begin time.Time
+	coolingDown := false
+	switch {
+	case now.Before(start) && hpaOverride.Spec.WarmUp != nil:
+		ramp, begin = hpaOverride.Spec.WarmUp, start.Add(-hpaOverride.Spec.WarmUp.Duration.Duration)
+	case !now.Before(end) && hpaOverride.Spec.CoolDown != nil:
+		ramp, begin, coolingDown = hpaOverride.Spec.CoolDown, end, true
+	case now.Before(end) && hpaOverride.Spec.CoolDown != nil:
+		return target, end
+	default:
+		return target, time.Time{}
+	}
+
+	// A ramp without steps has a step for every replica, up to defaultRampSteps.
+	from, to := int64(baseMinReplicas), int64(target)
+	steps := min(max(to-from, from-to), defaultRampSteps)
+	if ramp.Steps != nil {
+		steps = int64(*ramp.Steps)
+	}
+	interval := ramp.Duration.Duration / time.Duration(max(steps, 1))
+	step := int64(0)
+	if steps > 0 && interval > 0 {
+		step = min(int64(max(now.Sub(begin), 0)/interval), steps-1)
+	} else {
+		// There is nothing to ramp, the override applies in full.
+		steps, from = 1, to
+	}
+
+	// The level is the number of steps the minReplicas is away from the base minReplicas.
+	level := step + 1
+	if coolingDown {
+		level = steps - step
+	}
+	minReplicas := int32(from + (to-from)*level/steps)
+	switch {
+	case step+1 < steps:
+		return minReplicas, begin.Add(time.Duration(step+1) * interval)
+	case !coolingDown:
+		// Hold the last step until the override starts, then until it cools down.
+		return minReplicas, start
+	default:
+		return minReplicas, time.Time{}
+	}
+}
+
+// leadOverrides returns the HPAOverride objects with the lead time of each override that derives it from the startup
+// latency resolved to a fixed lead time, from the startup latency observed by the HorizontalPodAutoscalerX.
+func leadOverrides(hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpaOverrides []autoscalingxv1.HPAOverride) []autoscalingxv1.HPAOverride {
+	led := make([]autoscalingxv1.HPAOverride, 0, len(hpaOverrides))
+	for _, hpaOverride := range hpaOverrides {
+		if lead := hpaOverride.Spec.LeadTime; lead != nil && lead.FromStartupLatency {
+			hpaOverride = *hpaOverride.DeepCopy()
+			hpaOverride.Spec.LeadTime.FromStartupLatency = false
+			if latency := hpax.Status.StartupLatency; latency != nil {
+				hpaOverride.Spec.LeadTime.Duration.Duration += latency.Duration
+			}
+		}
+		led = append(led, hpaOverride)
+	}
+	return led
+}
+
+// leadOverride returns the HPAOverride with its lead time resolved from the longest startup latency observed by the
+// given HorizontalPodAutoscalerX objects, so that it is active for as long as any of them applies it.
+func leadOverride(hpaOverride *autoscalingxv1.HPAOverride, hpaxs []autoscalingxv1.HorizontalPodAutoscalerX) autoscalingxv1.HPAOverride {
+	led := *hpaOverride
+	for _, hpax := range hpaxs {
+		if candidate := leadOverrides(&hpax, []autoscalingxv1.HPAOverride{*hpaOverride})[0]; leadTime(&candidate) > leadTime(&led) {
+			led = candidate
+		}
+	}
+	return led
+}
+
+// leadTime returns the fixed lead time of the HPAOverride, which is 0 if it has none.
+func leadTime(hpaOverride *autoscalingxv1.HPAOverride) time.Duration {
+	if hpaOverride.Spec.LeadTime == nil {
+		return 0
+	}
+	return hpaOverride.Spec.LeadTime.Duration.Duration
+}
+
+// rampDuration returns the duration of the Ramp, which is 0 if it is nil.
+func rampDuration(ramp *autoscalingxv1.Ramp) time.Duration {
+	if ramp == nil {
+		return 0
+	}
+	return ramp.Duration.Duration
+}
+
+// relativeReference returns the replica count of the given reference.
+func relativeReference(reference autoscalingxv1.RelativeReference, hpax *autoscalingxv1.HorizontalPodAutoscalerX, hpa *autoscalingv2.HorizontalPodAutoscaler) int32 {
+	switch reference {
+	case autoscalingxv1.RelativeReferenceCurrentReplicas:
+		return hpa.Status.CurrentReplicas
+	case autoscalingxv1.RelativeReferenceDesiredReplicas:
+		return hpa.Status.DesiredReplicas
+	case autoscalingxv1.RelativeReferenceBaseMinReplicas:
+		return hpax.Spec.MinReplicas
+	default:
+		return 0
+	}
+}
+
+// selectOverride returns the active ScaleUp HPAOverride selected by the override strategy, or nil if none are active.
+func selectOverride(hpaOverrides []autoscalingxv1.HPAOverride, strategy autoscalingxv1.OverrideStrategy, now time.Time) *autoscalingxv1.HPAOverride {
+	return selectOverrideOfType(hpaOverrides, autoscalingxv1.OverrideTypeScaleUp, strategy, now)
+}
+
+// selectScaleDownOverride returns the active ScaleDown override with the highest minReplicas, which is the most
+// conservative one, or nil if there is none.
+func selectScaleDownOverride(hpaOverrides []autoscalingxv1.HPAOverride, now time.Time) *autoscalingxv1.HPAOverride {
+	return selectOverrideOfType(hpaOverrides, autoscalingxv1.OverrideTypeScaleDown, autoscalingxv1.OverrideStrategyMax, now)
+}
+
+// selectOverrideOfType returns the active HPAOverride of the given type preferred by the override strategy, or nil
+// if there is none. Ties are broken by namespace and name so that every reconciler agrees on the same override.
+func selectOverrideOfType(hpaOverrides []autoscalingxv1.HPAOverride, wantType autoscalingxv1.OverrideType, strategy autoscalingxv1.OverrideStrategy, now time.Time) *autoscalingxv1.HPAOverride {
+	var winner *autoscalingxv1.HPAOverride
+	for i := range hpaOverrides {
+		hpaOverride := &hpaOverrides[i]
+		if overrideType(hpaOverride) != wantType {
+			continue
+		}
+		if phase, _, err := overridePhase(hpaOverride, now); err != nil || phase != autoscalingxv1.HPAOverridePhaseActive {
+			continue
+		}
+		if winner == nil {
+			winner = hpaOverride
+			continue
+		}
+		if c := compareOverrides(hpaOverride, winner, strategy); c > 0 || (c == 0 && overrideKey(hpaOverride) < overrideKey(winner)) {
+			winner = hpaOverride
+		}
+	}
+	return winner
+}
+
+// compareOverrides returns a positive number if a is preferred over b by the override strategy, a negative number if
+// b is preferred, and 0 if neither is.
+func compareOverrides(a, b *autoscalingxv1.HPAOverride, strategy autoscalingxv1.OverrideStrategy) int {
+	switch strategy {
+	case autoscalingxv1.OverrideStrategyMin:
+		return cmp.Compare(b.Spec.MinReplicas, a.Spec.MinReplicas)
+	case autoscalingxv1.OverrideStrategyHighestPriority:
+		return cmp.Or(cmp.Compare(a.Spec.Priority, b.Spec.Priority), cmp.Compare(a.Spec.MinReplicas, b.Spec.MinReplicas))
+	case autoscalingxv1.OverrideStrategyLastCreated:
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	default:
+		return cmp.Compare(a.Spec.MinReplicas, b.Spec.MinReplicas)
+	}
+}
+
+// overrideType returns the type of the HPAOverride, which is ScaleUp if unset.
+func overrideType(hpaOverride *autoscalingxv1.HPAOverride) autoscalingxv1.OverrideType {
+	if hpaOverride.Spec.Type == "" {
+		return autoscalingxv1.OverrideTypeScaleUp
+	}
+	return hpaOverride.Spec.Type
+}
+
+// overrideKey returns the namespace/name key of the HPAOverride, which is just the name for a ClusterHPAOverride.
+func overrideKey(hpaOverride *autoscalingxv1.HPAOverride) string {
+	return client.ObjectKeyFromObject(hpaOverride).String()
+}
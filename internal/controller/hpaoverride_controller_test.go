@@ -0,0 +1,327 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+var _ = Describe("HPAOverride Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		BeforeEach(func() {
+			By("creating the associated HPA")
+			Expect(k8sClient.Create(ctx, defaultHpa.DeepCopy())).To(Succeed())
+
+			By("creating the custom resource for the Kind HorizontalPodAutoscalerX")
+			Expect(k8sClient.Create(ctx, defaultHpax.DeepCopy())).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("deleting the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{ObjectMeta: metav1.ObjectMeta{Name: hpaxName, Namespace: namespace}}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, hpax))).To(Succeed())
+
+			By("waiting for the HorizontalPodAutoscalerX to be finalized")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)
+				return apierrors.IsNotFound(err)
+			}, eventuallyTimeout, interval).Should(BeTrue())
+
+			By("deleting the associated HPA")
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: hpaName, Namespace: namespace}}
+			Expect(k8sClient.Delete(ctx, hpa)).To(Succeed())
+
+			By("deleting any HPAOverride")
+			hpaOverrideList := &autoscalingxv1.HPAOverrideList{}
+			Expect(k8sClient.List(ctx, hpaOverrideList)).To(Succeed())
+			for _, hpaOverride := range hpaOverrideList.Items {
+				Expect(k8sClient.Delete(ctx, &hpaOverride)).To(Succeed())
+			}
+		})
+
+		It("should mark an override that has not started as pending", func() {
+			By("creating an override that starts in the future")
+			start := fakeclock.Now().Add(1 * time.Hour).Truncate(time.Second)
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: start},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check its status")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Phase).To(Equal(autoscalingxv1.HPAOverridePhasePending))
+				g.Expect(hpaOverride.Status.Active).To(BeFalse())
+				g.Expect(hpaOverride.Status.StartTime).NotTo(BeNil())
+				g.Expect(hpaOverride.Status.StartTime.Time).To(BeTemporally("==", start))
+				g.Expect(hpaOverride.Status.EndTime).NotTo(BeNil())
+				g.Expect(hpaOverride.Status.EndTime.Time).To(BeTemporally("==", start.Add(1*time.Hour)))
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: false}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should mark an override that has ended as expired", func() {
+			By("creating an override that has ended")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-2 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check its status")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Phase).To(Equal(autoscalingxv1.HPAOverridePhaseExpired))
+				g.Expect(hpaOverride.Status.Active).To(BeFalse())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: false}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should mark the active override with the highest minReplicas as winning", func() {
+			By("creating an override that is active")
+			hpaOverride1 := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override-1", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride1)).To(Succeed())
+
+			By("creating another override that is active with a higher minReplicas")
+			hpaOverride2 := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override-2", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 20,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride2)).To(Succeed())
+
+			By("getting the overrides to check their status")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override-1", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Phase).To(Equal(autoscalingxv1.HPAOverridePhaseActive))
+				g.Expect(hpaOverride.Status.Active).To(BeTrue())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: false}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override-2", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Phase).To(Equal(autoscalingxv1.HPAOverridePhaseActive))
+				g.Expect(hpaOverride.Status.Active).To(BeTrue())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: true}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should not mark the selected override as winning when the floor wins", func() {
+			By("setting a floor above the minReplicas of the override")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.FloorMinReplicas = ptr.To(fallbackMinReplicas + 20)
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("creating an override that is active")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check it is active but not winning")
+			Consistently(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Active).To(BeTrue())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: false}))
+			}, consistentlyTimeout, interval).Should(Succeed())
+
+			By("lowering the floor below the minReplicas of the override")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Spec.FloorMinReplicas = ptr.To(fallbackMinReplicas)
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the override to check it is winning")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: true}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should list the HorizontalPodAutoscalerX objects selected by label as targets", func() {
+			By("labelling the HorizontalPodAutoscalerX")
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Labels = map[string]string{"event": "black-friday"}
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("creating an override that is active and selects the HorizontalPodAutoscalerX")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Hour},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-1 * time.Hour)},
+					},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"event": "black-friday"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check its status")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Targets).To(ConsistOf(autoscalingxv1.HPAOverrideTarget{Name: hpaxName, Winning: true}))
+			}, eventuallyTimeout, interval).Should(Succeed())
+
+			By("removing the label from the HorizontalPodAutoscalerX")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: hpaxName, Namespace: namespace}, hpax)).To(Succeed())
+			hpax.Labels = nil
+			Expect(k8sClient.Update(ctx, hpax)).To(Succeed())
+
+			By("getting the override to check it has no targets")
+			Eventually(func(g Gomega) {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				g.Expect(hpaOverride.Status.Targets).To(BeEmpty())
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+
+		It("should move an override from pending to active to expired as the clock advances", func() {
+			By("creating an override that starts in the future")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 2 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(2 * time.Second)},
+					},
+					HPATargetName: hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check it is pending")
+			Eventually(func() autoscalingxv1.HPAOverridePhase {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				return hpaOverride.Status.Phase
+			}, eventuallyTimeout, interval).Should(Equal(autoscalingxv1.HPAOverridePhasePending))
+
+			By("advancing the clock to the start of the override")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the override to check it is active")
+			Eventually(func() autoscalingxv1.HPAOverridePhase {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				return hpaOverride.Status.Phase
+			}, requeueTimeout, interval).Should(Equal(autoscalingxv1.HPAOverridePhaseActive))
+
+			By("advancing the clock to the end of the override")
+			fakeclock.Step(2 * time.Second)
+
+			By("getting the override to check it is expired")
+			Eventually(func() autoscalingxv1.HPAOverridePhase {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				return hpaOverride.Status.Phase
+			}, requeueTimeout, interval).Should(Equal(autoscalingxv1.HPAOverridePhaseExpired))
+		})
+
+		It("should delete an expired override once its ttlAfterExpiry has passed", func() {
+			By("creating an override that expired 1s ago with a ttlAfterExpiry of 2s")
+			hpaOverride := &autoscalingxv1.HPAOverride{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: namespace},
+				Spec: autoscalingxv1.HPAOverrideSpec{
+					OverrideSpec: autoscalingxv1.OverrideSpec{
+						MinReplicas: fallbackMinReplicas + 10,
+						Duration:    metav1.Duration{Duration: 1 * time.Second},
+						Time:        metav1.Time{Time: fakeclock.Now().Add(-2 * time.Second)},
+					},
+					TTLAfterExpiry: &metav1.Duration{Duration: 2 * time.Second},
+					HPATargetName:  hpaName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, hpaOverride)).To(Succeed())
+
+			By("getting the override to check it is kept while expired")
+			Eventually(func() autoscalingxv1.HPAOverridePhase {
+				hpaOverride := &autoscalingxv1.HPAOverride{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, hpaOverride)).To(Succeed())
+				return hpaOverride.Status.Phase
+			}, eventuallyTimeout, interval).Should(Equal(autoscalingxv1.HPAOverridePhaseExpired))
+
+			By("advancing the clock past the ttlAfterExpiry")
+			fakeclock.Step(1 * time.Second)
+
+			By("getting the override to check it is deleted")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "some-override", Namespace: namespace}, &autoscalingxv1.HPAOverride{})
+				return apierrors.IsNotFound(err)
+			}, requeueTimeout, interval).Should(BeTrue())
+
+			By("listing the events to check the deletion is reported on the HorizontalPodAutoscalerX")
+			Eventually(func(g Gomega) {
+				events := &corev1.EventList{}
+				g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace))).To(Succeed())
+				g.Expect(events.Items).To(ContainElement(And(
+					HaveField("InvolvedObject.Name", hpaxName),
+					HaveField("Reason", "DeletingExpiredOverride"),
+					HaveField("Message", ContainSubstring("some-override")),
+				)))
+			}, eventuallyTimeout, interval).Should(Succeed())
+		})
+	})
+})
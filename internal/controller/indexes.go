@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+const (
+	// hpaTargetNameField is the field index of the HPA targeted by HorizontalPodAutoscalerX and HPAOverride objects.
+	hpaTargetNameField = "spec.hpaTargetName"
+
+	// hasSelectorField is the field index of HPAOverride objects that target HorizontalPodAutoscalerX objects
+	// by a label selector rather than by the name of their HPA.
+	hasSelectorField = "spec.hasSelector"
+)
+
+// SetupFieldIndexes registers the field indexes shared by the controllers in this package.
+// It must be called once before the controllers are set up with the manager.
+func SetupFieldIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	err := indexer.IndexField(
+		ctx,
+		&autoscalingxv1.HorizontalPodAutoscalerX{},
+		hpaTargetNameField,
+		func(obj client.Object) []string {
+			return []string{obj.(*autoscalingxv1.HorizontalPodAutoscalerX).Spec.HPATargetName}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = indexer.IndexField(
+		ctx,
+		&autoscalingxv1.HPAOverride{},
+		hpaTargetNameField,
+		func(obj client.Object) []string {
+			return []string{obj.(*autoscalingxv1.HPAOverride).Spec.HPATargetName}
+		})
+	if err != nil {
+		return err
+	}
+
+	return indexer.IndexField(
+		ctx,
+		&autoscalingxv1.HPAOverride{},
+		hasSelectorField,
+		func(obj client.Object) []string {
+			return []string{strconv.FormatBool(obj.(*autoscalingxv1.HPAOverride).Spec.Selector != nil)}
+		})
+}
+
+// listHPAOverridesForHPAX lists the HPAOverride objects that target the HorizontalPodAutoscalerX, either by the
+// name of its HPA or by a label selector matching its labels.
+func listHPAOverridesForHPAX(ctx context.Context, c client.Reader, hpax *autoscalingxv1.HorizontalPodAutoscalerX) ([]autoscalingxv1.HPAOverride, error) {
+	byName := &autoscalingxv1.HPAOverrideList{}
+	if err := c.List(ctx, byName, &client.ListOptions{
+		Namespace:     hpax.Namespace,
+		FieldSelector: fields.OneTermEqualSelector(hpaTargetNameField, hpax.Spec.HPATargetName),
+	}); err != nil {
+		return nil, err
+	}
+
+	bySelector := &autoscalingxv1.HPAOverrideList{}
+	if err := c.List(ctx, bySelector, &client.ListOptions{
+		Namespace:     hpax.Namespace,
+		FieldSelector: fields.OneTermEqualSelector(hasSelectorField, "true"),
+	}); err != nil {
+		return nil, err
+	}
+
+	hpaOverrides := byName.Items
+	for _, hpaOverride := range bySelector.Items {
+		selector, err := metav1.LabelSelectorAsSelector(hpaOverride.Spec.Selector)
+		if err != nil {
+			// An invalid selector matches nothing.
+			continue
+		}
+		if selector.Matches(labels.Set(hpax.Labels)) {
+			hpaOverrides = append(hpaOverrides, hpaOverride)
+		}
+	}
+	return hpaOverrides, nil
+}
+
+// listHPAXForHPAOverride lists the HorizontalPodAutoscalerX objects targeted by the HPAOverride, either by the
+// name of their HPA or by its label selector.
+func listHPAXForHPAOverride(ctx context.Context, c client.Reader, hpaOverride *autoscalingxv1.HPAOverride) ([]autoscalingxv1.HorizontalPodAutoscalerX, error) {
+	listOptions := &client.ListOptions{Namespace: hpaOverride.Namespace}
+	if hpaOverride.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(hpaOverride.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector: %w", err)
+		}
+		listOptions.LabelSelector = selector
+	} else {
+		listOptions.FieldSelector = fields.OneTermEqualSelector(hpaTargetNameField, hpaOverride.Spec.HPATargetName)
+	}
+
+	hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
+	if err := c.List(ctx, hpaxList, listOptions); err != nil {
+		return nil, err
+	}
+	return hpaxList.Items, nil
+}
+
+// listClusterHPAOverridesForHPAX lists the ClusterHPAOverride objects whose namespace and label selectors match the
+// HorizontalPodAutoscalerX.
+func listClusterHPAOverridesForHPAX(ctx context.Context, c client.Reader, hpax *autoscalingxv1.HorizontalPodAutoscalerX) ([]autoscalingxv1.ClusterHPAOverride, error) {
+	clusterHPAOverrideList := &autoscalingxv1.ClusterHPAOverrideList{}
+	if err := c.List(ctx, clusterHPAOverrideList); err != nil {
+		return nil, err
+	}
+	if len(clusterHPAOverrideList.Items) == 0 {
+		return nil, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: hpax.Namespace}, namespace); err != nil {
+		return nil, err
+	}
+
+	var clusterHPAOverrides []autoscalingxv1.ClusterHPAOverride
+	for _, clusterHPAOverride := range clusterHPAOverrideList.Items {
+		if selectorMatches(clusterHPAOverride.Spec.NamespaceSelector, namespace.Labels) &&
+			selectorMatches(clusterHPAOverride.Spec.Selector, hpax.Labels) {
+			clusterHPAOverrides = append(clusterHPAOverrides, clusterHPAOverride)
+		}
+	}
+	return clusterHPAOverrides, nil
+}
+
+// listHPAXForClusterHPAOverride lists the HorizontalPodAutoscalerX objects selected by the namespace and label
+// selectors of the ClusterHPAOverride.
+func listHPAXForClusterHPAOverride(ctx context.Context, c client.Reader, clusterHPAOverride *autoscalingxv1.ClusterHPAOverride) ([]autoscalingxv1.HorizontalPodAutoscalerX, error) {
+	namespaceListOptions := &client.ListOptions{}
+	if clusterHPAOverride.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(clusterHPAOverride.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing namespace selector: %w", err)
+		}
+		namespaceListOptions.LabelSelector = selector
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaceList, namespaceListOptions); err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]bool, len(namespaceList.Items))
+	for _, namespace := range namespaceList.Items {
+		namespaces[namespace.Name] = true
+	}
+
+	hpaxListOptions := &client.ListOptions{}
+	if clusterHPAOverride.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(clusterHPAOverride.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector: %w", err)
+		}
+		hpaxListOptions.LabelSelector = selector
+	}
+	hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
+	if err := c.List(ctx, hpaxList, hpaxListOptions); err != nil {
+		return nil, err
+	}
+
+	var hpaxs []autoscalingxv1.HorizontalPodAutoscalerX
+	for _, hpax := range hpaxList.Items {
+		if namespaces[hpax.Namespace] {
+			hpaxs = append(hpaxs, hpax)
+		}
+	}
+	return hpaxs, nil
+}
+
+// listOverridesForHPAX lists the HPAOverride and ClusterHPAOverride objects that target the HorizontalPodAutoscalerX.
+// ClusterHPAOverride objects are returned as HPAOverride objects without a namespace so that both kinds can be
+// evaluated together.
+func listOverridesForHPAX(ctx context.Context, c client.Reader, hpax *autoscalingxv1.HorizontalPodAutoscalerX) ([]autoscalingxv1.HPAOverride, error) {
+	hpaOverrides, err := listHPAOverridesForHPAX(ctx, c, hpax)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterHPAOverrides, err := listClusterHPAOverridesForHPAX(ctx, c, hpax)
+	if err != nil {
+		return nil, err
+	}
+	for _, clusterHPAOverride := range clusterHPAOverrides {
+		hpaOverrides = append(hpaOverrides, clusterHPAOverride.AsHPAOverride())
+	}
+	return hpaOverrides, nil
+}
+
+// selectorMatches reports whether the label selector matches the labels. A nil selector matches everything and an
+// invalid selector matches nothing.
+func selectorMatches(labelSelector *metav1.LabelSelector, set map[string]string) bool {
+	if labelSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(set))
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "hpax"
+)
+
+var (
+	minReplicasGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "min_replicas",
+		Help:      "The effective minReplicas computed for the HPA targeted by the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	maxReplicasGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "max_replicas",
+		Help:      "The effective maxReplicas computed for the HPA targeted by the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	baseMinReplicasGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "base_min_replicas",
+		Help:      "The base minReplicas of the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	fallbackMinReplicasGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "fallback_min_replicas",
+		Help:      "The minReplicas suggested by the fallback of the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	overrideMinReplicasGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "override_min_replicas",
+		Help:      "The minReplicas suggested by the active HPAOverrides of the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	fallbackEngagedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "fallback_engaged",
+		Help:      "Whether the fallback of the HorizontalPodAutoscalerX is engaged (1) or not (0).",
+	}, []string{"namespace", "name"})
+
+	scalingInactiveSinceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "scaling_inactive_since_timestamp_seconds",
+		Help:      "The Unix time since which the ScalingActive condition of the HPA has been False, or 0 if it is not False.",
+	}, []string{"namespace", "name"})
+
+	activeOverridesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "active_overrides",
+		Help:      "The number of active HPAOverrides for the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	dryRunGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "dry_run",
+		Help:      "Whether the HorizontalPodAutoscalerX is in DryRun mode (1), so the effective replicas aren't applied to the HPA, or not (0).",
+	}, []string{"namespace", "name"})
+
+	fallbackActivationsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "fallback_activations_total",
+		Help:      "The number of times the fallback of the HorizontalPodAutoscalerX was engaged.",
+	}, []string{"namespace", "name"})
+
+	hpaPatchFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "hpa_patch_failures_total",
+		Help:      "The number of failed patches of the HPA targeted by the HorizontalPodAutoscalerX.",
+	}, []string{"namespace", "name"})
+
+	writesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "writes_total",
+		Help:      "The number of writes of the HPA, of the HPA from the hpaTemplate or of the status of the HorizontalPodAutoscalerX, by whether they were performed or skipped as no-ops.",
+	}, []string{"namespace", "name", "resource", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		minReplicasGauge,
+		maxReplicasGauge,
+		baseMinReplicasGauge,
+		fallbackMinReplicasGauge,
+		overrideMinReplicasGauge,
+		fallbackEngagedGauge,
+		scalingInactiveSinceGauge,
+		activeOverridesGauge,
+		dryRunGauge,
+		fallbackActivationsCounter,
+		hpaPatchFailuresCounter,
+		writesCounter,
+	)
+}
+
+// deleteMetrics deletes the metrics of the HorizontalPodAutoscalerX with the given namespace and name.
+func deleteMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	minReplicasGauge.Delete(labels)
+	maxReplicasGauge.Delete(labels)
+	baseMinReplicasGauge.Delete(labels)
+	fallbackMinReplicasGauge.Delete(labels)
+	overrideMinReplicasGauge.Delete(labels)
+	fallbackEngagedGauge.Delete(labels)
+	scalingInactiveSinceGauge.Delete(labels)
+	activeOverridesGauge.Delete(labels)
+	dryRunGauge.Delete(labels)
+	fallbackActivationsCounter.Delete(labels)
+	hpaPatchFailuresCounter.Delete(labels)
+	writesCounter.DeletePartialMatch(labels)
+}
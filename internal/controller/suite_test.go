@@ -77,7 +77,26 @@ var _ = BeforeSuite(func() {
 
 	fakeclock = clock.NewFakeClock(time.Date(1997, time.November, 7, 0, 0, 0, 0, time.UTC))
 
+	err = SetupFieldIndexes(ctx, k8sManager.GetFieldIndexer())
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&HorizontalPodAutoscalerXReconciler{
+		Client:        k8sManager.GetClient(),
+		Scheme:        k8sManager.GetScheme(),
+		EventRecorder: k8sManager.GetEventRecorderFor(ControllerName),
+		Clock:         fakeclock,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&HPAOverrideReconciler{
+		Client:        k8sManager.GetClient(),
+		Scheme:        k8sManager.GetScheme(),
+		EventRecorder: k8sManager.GetEventRecorderFor(HPAOverrideControllerName),
+		Clock:         fakeclock,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&ClusterHPAOverrideReconciler{
 		Client: k8sManager.GetClient(),
 		Scheme: k8sManager.GetScheme(),
 		Clock:  fakeclock,
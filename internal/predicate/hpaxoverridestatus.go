@@ -0,0 +1,35 @@
+package predicate
+
+import (
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+// HPAXOverrideStatusChangedPredicate focuses only on the HorizontalPodAutoscalerX status fields the overrides report
+type HPAXOverrideStatusChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for validating HorizontalPodAutoscalerX specific changes
+func (HPAXOverrideStatusChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldHPAX, ok := e.ObjectOld.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return false
+	}
+
+	newHPAX, ok := e.ObjectNew.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return false
+	}
+
+	return !reflect.DeepEqual(oldHPAX.Status.Winner, newHPAX.Status.Winner) ||
+		!reflect.DeepEqual(oldHPAX.Status.StartupLatency, newHPAX.Status.StartupLatency)
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var clusterhpaoverridelog = logf.Log.WithName("clusterhpaoverride-resource")
+
+// SetupClusterHPAOverrideWebhookWithManager registers the webhook for ClusterHPAOverride in the manager.
+func SetupClusterHPAOverrideWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&autoscalingxv1.ClusterHPAOverride{}).
+		WithValidator(&ClusterHPAOverrideCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-autoscalingx-rrethy-io-v1-clusterhpaoverride,mutating=false,failurePolicy=fail,sideEffects=None,groups=autoscalingx.rrethy.io,resources=clusterhpaoverrides,verbs=create;update,versions=v1,name=vclusterhpaoverride-v1.kb.io,admissionReviewVersions=v1
+
+// ClusterHPAOverrideCustomValidator struct is responsible for validating the ClusterHPAOverride resource
+// when it is created, updated, or deleted.
+type ClusterHPAOverrideCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ClusterHPAOverrideCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ClusterHPAOverride.
+func (v *ClusterHPAOverrideCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterHPAOverride, ok := obj.(*autoscalingxv1.ClusterHPAOverride)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterHPAOverride object but got %T", obj)
+	}
+	clusterhpaoverridelog.Info("Validation for ClusterHPAOverride upon creation", "name", clusterHPAOverride.GetName())
+
+	return nil, v.validate(clusterHPAOverride)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ClusterHPAOverride.
+func (v *ClusterHPAOverrideCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	clusterHPAOverride, ok := newObj.(*autoscalingxv1.ClusterHPAOverride)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterHPAOverride object for the newObj but got %T", newObj)
+	}
+	clusterhpaoverridelog.Info("Validation for ClusterHPAOverride upon update", "name", clusterHPAOverride.GetName())
+
+	return nil, v.validate(clusterHPAOverride)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type ClusterHPAOverride.
+func (v *ClusterHPAOverrideCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the ClusterHPAOverride against its own spec, the same way as a HPAOverride.
+func (v *ClusterHPAOverrideCustomValidator) validate(clusterHPAOverride *autoscalingxv1.ClusterHPAOverride) error {
+	if err := validateOverrideSpec(&clusterHPAOverride.Spec.OverrideSpec); err != nil {
+		return err
+	}
+	if clusterHPAOverride.Spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(clusterHPAOverride.Spec.Selector); err != nil {
+			return fmt.Errorf("spec.selector is invalid: %w", err)
+		}
+	}
+	if clusterHPAOverride.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(clusterHPAOverride.Spec.NamespaceSelector); err != nil {
+			return fmt.Errorf("spec.namespaceSelector is invalid: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+var _ = Describe("ClusterHPAOverride Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *autoscalingxv1.ClusterHPAOverride
+		oldObj    *autoscalingxv1.ClusterHPAOverride
+		validator ClusterHPAOverrideCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &autoscalingxv1.ClusterHPAOverride{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-override"},
+			Spec: autoscalingxv1.ClusterHPAOverrideSpec{
+				OverrideSpec: autoscalingxv1.OverrideSpec{
+					MinReplicas: 20,
+					Duration:    metav1.Duration{Duration: 1 * time.Hour},
+					Time:        metav1.Now(),
+				},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = ClusterHPAOverrideCustomValidator{}
+	})
+
+	Context("When creating or updating ClusterHPAOverride under Validating Webhook", func() {
+		It("Should admit a valid ClusterHPAOverride", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a zero duration", func() {
+			obj.Spec.Duration = metav1.Duration{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.duration")))
+		})
+
+		It("Should deny a negative lead time", func() {
+			obj.Spec.LeadTime = &autoscalingxv1.LeadTime{Duration: metav1.Duration{Duration: -1 * time.Minute}}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.leadTime.duration")))
+		})
+
+		It("Should deny a ramp without a positive duration", func() {
+			obj.Spec.WarmUp = &autoscalingxv1.Ramp{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.warmUp.duration")))
+		})
+
+		It("Should deny a minReplicas exceeding the maxReplicas of the override", func() {
+			obj.Spec.MaxReplicas = ptr.To(int32(10))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.maxReplicas")))
+		})
+
+		It("Should deny an invalid selector", func() {
+			obj.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.selector")))
+		})
+
+		It("Should deny an invalid namespace selector", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.namespaceSelector")))
+		})
+
+		It("Should deny an invalid cron expression", func() {
+			obj.Spec.Time = metav1.Time{}
+			obj.Spec.Schedule = &autoscalingxv1.Schedule{Cron: "every day"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.schedule.cron")))
+		})
+
+		It("Should deny an invalid time zone", func() {
+			obj.Spec.Time = metav1.Time{}
+			obj.Spec.Schedule = &autoscalingxv1.Schedule{Cron: "0 * * * *", TimeZone: "Mars/Olympus_Mons"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.schedule.timeZone")))
+		})
+	})
+})
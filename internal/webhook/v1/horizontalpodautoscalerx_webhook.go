@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var horizontalpodautoscalerxlog = logf.Log.WithName("horizontalpodautoscalerx-resource")
+
+// SetupHorizontalPodAutoscalerXWebhookWithManager registers the webhook for HorizontalPodAutoscalerX in the manager.
+func SetupHorizontalPodAutoscalerXWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&autoscalingxv1.HorizontalPodAutoscalerX{}).
+		WithValidator(&HorizontalPodAutoscalerXCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-autoscalingx-rrethy-io-v1-horizontalpodautoscalerx,mutating=false,failurePolicy=fail,sideEffects=None,groups=autoscalingx.rrethy.io,resources=horizontalpodautoscalerxs,verbs=create;update,versions=v1,name=vhorizontalpodautoscalerx-v1.kb.io,admissionReviewVersions=v1
+
+// HorizontalPodAutoscalerXCustomValidator struct is responsible for validating the HorizontalPodAutoscalerX resource
+// when it is created, updated, or deleted.
+type HorizontalPodAutoscalerXCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &HorizontalPodAutoscalerXCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type HorizontalPodAutoscalerX.
+func (v *HorizontalPodAutoscalerXCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hpax, ok := obj.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return nil, fmt.Errorf("expected a HorizontalPodAutoscalerX object but got %T", obj)
+	}
+	horizontalpodautoscalerxlog.Info("Validation for HorizontalPodAutoscalerX upon creation", "name", hpax.GetName())
+
+	return nil, v.validate(ctx, hpax)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type HorizontalPodAutoscalerX.
+func (v *HorizontalPodAutoscalerXCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	hpax, ok := newObj.(*autoscalingxv1.HorizontalPodAutoscalerX)
+	if !ok {
+		return nil, fmt.Errorf("expected a HorizontalPodAutoscalerX object for the newObj but got %T", newObj)
+	}
+	horizontalpodautoscalerxlog.Info("Validation for HorizontalPodAutoscalerX upon update", "name", hpax.GetName())
+
+	if !hpax.DeletionTimestamp.IsZero() {
+		// Don't block removing the finalizer of an object that is being deleted.
+		return nil, nil
+	}
+	return nil, v.validate(ctx, hpax)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type HorizontalPodAutoscalerX.
+func (v *HorizontalPodAutoscalerXCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the HorizontalPodAutoscalerX against its own spec and the other HorizontalPodAutoscalerX
+// objects in its namespace.
+func (v *HorizontalPodAutoscalerXCustomValidator) validate(ctx context.Context, hpax *autoscalingxv1.HorizontalPodAutoscalerX) error {
+	if hpax.Spec.Fallback != nil && hpax.Spec.Fallback.MinReplicas < hpax.Spec.MinReplicas {
+		return fmt.Errorf("spec.fallback.minReplicas (%d) must not be lower than spec.minReplicas (%d)",
+			hpax.Spec.Fallback.MinReplicas, hpax.Spec.MinReplicas)
+	}
+
+	if hpax.Spec.Fallback != nil {
+		previous := autoscalingxv1.FallbackTier{MinReplicas: hpax.Spec.Fallback.MinReplicas}
+		for i, tier := range hpax.Spec.Fallback.Tiers {
+			if i > 0 && tier.After.Duration <= previous.After.Duration {
+				return fmt.Errorf("spec.fallback.tiers[%d].after (%s) must be greater than the previous tier's (%s)",
+					i, tier.After.Duration, previous.After.Duration)
+			}
+			if tier.MinReplicas < previous.MinReplicas {
+				return fmt.Errorf("spec.fallback.tiers[%d].minReplicas (%d) must not be lower than the previous tier's (%d)",
+					i, tier.MinReplicas, previous.MinReplicas)
+			}
+			if maxReplicas := hpax.Spec.MaxReplicas; maxReplicas != nil && tier.MinReplicas > *maxReplicas {
+				return fmt.Errorf("spec.fallback.tiers[%d].minReplicas (%d) must not exceed spec.maxReplicas (%d)", i, tier.MinReplicas, *maxReplicas)
+			}
+			previous = tier
+		}
+
+		if recovery := hpax.Spec.Fallback.Recovery; recovery != nil {
+			if recovery.Duration.Duration < 0 {
+				return fmt.Errorf("spec.fallback.recovery.duration (%s) must not be negative", recovery.Duration.Duration)
+			}
+			if recovery.StepDown != nil && recovery.StepDown.Interval.Duration <= 0 {
+				return fmt.Errorf("spec.fallback.recovery.stepDown.interval (%s) must be greater than 0", recovery.StepDown.Interval.Duration)
+			}
+		}
+	}
+
+	if floor := hpax.Spec.FloorMinReplicas; floor != nil && *floor > hpax.Spec.MinReplicas {
+		return fmt.Errorf("spec.floorMinReplicas (%d) must not exceed spec.minReplicas (%d)", *floor, hpax.Spec.MinReplicas)
+	}
+
+	if template := hpax.Spec.HPATemplate; template != nil && hpax.Spec.MaxReplicas == nil && hpax.Spec.MinReplicas > template.MaxReplicas {
+		return fmt.Errorf("spec.minReplicas (%d) must not exceed spec.hpaTemplate.maxReplicas (%d)", hpax.Spec.MinReplicas, template.MaxReplicas)
+	}
+
+	if maxReplicas := hpax.Spec.MaxReplicas; maxReplicas != nil {
+		if hpax.Spec.MinReplicas > *maxReplicas {
+			return fmt.Errorf("spec.minReplicas (%d) must not exceed spec.maxReplicas (%d)", hpax.Spec.MinReplicas, *maxReplicas)
+		}
+		if hpax.Spec.Fallback != nil && hpax.Spec.Fallback.MinReplicas > *maxReplicas {
+			return fmt.Errorf("spec.fallback.minReplicas (%d) must not exceed spec.maxReplicas (%d)", hpax.Spec.Fallback.MinReplicas, *maxReplicas)
+		}
+	}
+
+	hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
+	if err := v.Client.List(ctx, hpaxList, client.InNamespace(hpax.Namespace)); err != nil {
+		return fmt.Errorf("listing HorizontalPodAutoscalerX: %w", err)
+	}
+	for _, other := range hpaxList.Items {
+		if other.Name == hpax.Name || other.Spec.HPATargetName != hpax.Spec.HPATargetName {
+			continue
+		}
+		return fmt.Errorf("hpa %q is already targeted by HorizontalPodAutoscalerX %q", hpax.Spec.HPATargetName, other.Name)
+	}
+
+	return nil
+}
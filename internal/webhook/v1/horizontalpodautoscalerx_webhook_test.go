@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+var _ = Describe("HorizontalPodAutoscalerX Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *autoscalingxv1.HorizontalPodAutoscalerX
+		oldObj    *autoscalingxv1.HorizontalPodAutoscalerX
+		validator HorizontalPodAutoscalerXCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &autoscalingxv1.HorizontalPodAutoscalerX{
+			ObjectMeta: metav1.ObjectMeta{Name: "myhpax", Namespace: "default"},
+			Spec: autoscalingxv1.HorizontalPodAutoscalerXSpec{
+				HPATargetName: "myhpa",
+				MinReplicas:   1,
+				Fallback:      &autoscalingxv1.Fallback{MinReplicas: 10},
+			},
+		}
+		oldObj = obj.DeepCopy()
+		validator = HorizontalPodAutoscalerXCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+	})
+
+	Context("When creating or updating HorizontalPodAutoscalerX under Validating Webhook", func() {
+		It("Should admit a valid HorizontalPodAutoscalerX", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a fallback minReplicas lower than minReplicas", func() {
+			obj.Spec.Fallback.MinReplicas = 0
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.minReplicas")))
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.minReplicas")))
+		})
+
+		It("Should deny a minReplicas exceeding maxReplicas", func() {
+			obj.Spec.MinReplicas = 20
+			obj.Spec.Fallback = nil
+			obj.Spec.MaxReplicas = ptr.To(int32(10))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.minReplicas")))
+		})
+
+		It("Should deny a fallback minReplicas exceeding maxReplicas", func() {
+			obj.Spec.MaxReplicas = ptr.To(int32(5))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.minReplicas")))
+		})
+
+		It("Should deny a minReplicas exceeding the maxReplicas of the hpaTemplate", func() {
+			obj.Spec.MinReplicas = 20
+			obj.Spec.Fallback = nil
+			obj.Spec.HPATemplate = &autoscalingxv1.HPATemplate{MaxReplicas: 10}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.hpaTemplate.maxReplicas")))
+
+			obj.Spec.MaxReplicas = ptr.To(int32(30))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a floorMinReplicas exceeding the minReplicas", func() {
+			obj.Spec.FloorMinReplicas = ptr.To(obj.Spec.MinReplicas + 1)
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.floorMinReplicas")))
+
+			obj.Spec.FloorMinReplicas = ptr.To(obj.Spec.MinReplicas)
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit escalating fallback tiers", func() {
+			obj.Spec.Fallback.Tiers = []autoscalingxv1.FallbackTier{
+				{MinReplicas: 50, After: metav1.Duration{Duration: 10 * time.Minute}},
+				{MinReplicas: 100, After: metav1.Duration{Duration: 30 * time.Minute}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny fallback tiers that are not ordered by after", func() {
+			obj.Spec.Fallback.Tiers = []autoscalingxv1.FallbackTier{
+				{MinReplicas: 50, After: metav1.Duration{Duration: 30 * time.Minute}},
+				{MinReplicas: 100, After: metav1.Duration{Duration: 10 * time.Minute}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.tiers[1].after")))
+		})
+
+		It("Should deny a fallback tier with a lower minReplicas than the previous tier", func() {
+			obj.Spec.Fallback.Tiers = []autoscalingxv1.FallbackTier{
+				{MinReplicas: 5, After: metav1.Duration{Duration: 10 * time.Minute}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.tiers[0].minReplicas")))
+		})
+
+		It("Should admit a fallback recovery with a step-down", func() {
+			obj.Spec.Fallback.Recovery = &autoscalingxv1.FallbackRecovery{
+				Duration: metav1.Duration{Duration: 10 * time.Minute},
+				StepDown: &autoscalingxv1.FallbackStepDown{Steps: 3, Interval: metav1.Duration{Duration: 5 * time.Minute}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a fallback recovery step-down without an interval", func() {
+			obj.Spec.Fallback.Recovery = &autoscalingxv1.FallbackRecovery{
+				StepDown: &autoscalingxv1.FallbackStepDown{Steps: 3},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.fallback.recovery.stepDown.interval")))
+		})
+
+		It("Should deny a HorizontalPodAutoscalerX targeting an HPA already targeted by another", func() {
+			other := obj.DeepCopy()
+			other.Name = "other-hpax"
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(other).Build()
+
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("already targeted")))
+		})
+
+		It("Should admit updating a HorizontalPodAutoscalerX that is the only one targeting its HPA", func() {
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(oldObj).Build()
+
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit a HorizontalPodAutoscalerX targeting an HPA in another namespace", func() {
+			other := obj.DeepCopy()
+			other.Name = "other-hpax"
+			other.Namespace = "other"
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(other).Build()
+
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var hpaoverridelog = logf.Log.WithName("hpaoverride-resource")
+
+// SetupHPAOverrideWebhookWithManager registers the webhook for HPAOverride in the manager.
+func SetupHPAOverrideWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&autoscalingxv1.HPAOverride{}).
+		WithValidator(&HPAOverrideCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-autoscalingx-rrethy-io-v1-hpaoverride,mutating=false,failurePolicy=fail,sideEffects=None,groups=autoscalingx.rrethy.io,resources=hpaoverrides,verbs=create;update,versions=v1,name=vhpaoverride-v1.kb.io,admissionReviewVersions=v1
+
+// HPAOverrideCustomValidator struct is responsible for validating the HPAOverride resource
+// when it is created, updated, or deleted.
+type HPAOverrideCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &HPAOverrideCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type HPAOverride.
+func (v *HPAOverrideCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hpaOverride, ok := obj.(*autoscalingxv1.HPAOverride)
+	if !ok {
+		return nil, fmt.Errorf("expected a HPAOverride object but got %T", obj)
+	}
+	hpaoverridelog.Info("Validation for HPAOverride upon creation", "name", hpaOverride.GetName())
+
+	return nil, v.validate(ctx, hpaOverride)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type HPAOverride.
+func (v *HPAOverrideCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	hpaOverride, ok := newObj.(*autoscalingxv1.HPAOverride)
+	if !ok {
+		return nil, fmt.Errorf("expected a HPAOverride object for the newObj but got %T", newObj)
+	}
+	hpaoverridelog.Info("Validation for HPAOverride upon update", "name", hpaOverride.GetName())
+
+	return nil, v.validate(ctx, hpaOverride)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type HPAOverride.
+func (v *HPAOverrideCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the HPAOverride against its own spec and the HPA it targets.
+func (v *HPAOverrideCustomValidator) validate(ctx context.Context, hpaOverride *autoscalingxv1.HPAOverride) error {
+	if err := validateOverrideSpec(&hpaOverride.Spec.OverrideSpec); err != nil {
+		return err
+	}
+	if ttl := hpaOverride.Spec.TTLAfterExpiry; ttl != nil && ttl.Duration < 0 {
+		return fmt.Errorf("spec.ttlAfterExpiry (%s) must not be negative", ttl.Duration)
+	}
+	if hpaOverride.Spec.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(hpaOverride.Spec.Selector); err != nil {
+			return fmt.Errorf("spec.selector is invalid: %w", err)
+		}
+	}
+	if hpaOverride.Spec.MaxReplicas != nil {
+		// The override raises or lowers the ceiling itself, so the HPA's current maxReplicas doesn't apply.
+		return nil
+	}
+
+	hpaNames := []string{hpaOverride.Spec.HPATargetName}
+	if hpaOverride.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(hpaOverride.Spec.Selector)
+		if err != nil {
+			return fmt.Errorf("spec.selector is invalid: %w", err)
+		}
+		hpaxList := &autoscalingxv1.HorizontalPodAutoscalerXList{}
+		if err := v.Client.List(ctx, hpaxList, client.InNamespace(hpaOverride.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return fmt.Errorf("listing HorizontalPodAutoscalerX: %w", err)
+		}
+		hpaNames = hpaNames[:0]
+		for _, hpax := range hpaxList.Items {
+			hpaNames = append(hpaNames, hpax.Spec.HPATargetName)
+		}
+	}
+
+	for _, hpaName := range hpaNames {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		err := v.Client.Get(ctx, client.ObjectKey{Name: hpaName, Namespace: hpaOverride.Namespace}, hpa)
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("getting hpa %q: %w", hpaName, err)
+		}
+		// The HPA may be created after the override, in which case there is nothing to check against yet.
+		if err == nil && hpaOverride.Spec.MinReplicas > hpa.Spec.MaxReplicas {
+			return fmt.Errorf("spec.minReplicas (%d) must not exceed the maxReplicas (%d) of hpa %q",
+				hpaOverride.Spec.MinReplicas, hpa.Spec.MaxReplicas, hpaName)
+		}
+	}
+
+	return nil
+}
+
+// validateOverrideSpec validates the override spec shared by HPAOverride and ClusterHPAOverride on its own.
+func validateOverrideSpec(spec *autoscalingxv1.OverrideSpec) error {
+	if spec.Duration.Duration <= 0 {
+		return fmt.Errorf("spec.duration (%s) must be positive", spec.Duration.Duration)
+	}
+	if lead := spec.LeadTime; lead != nil && lead.Duration.Duration < 0 {
+		return fmt.Errorf("spec.leadTime.duration (%s) must not be negative", lead.Duration.Duration)
+	}
+	if warmUp := spec.WarmUp; warmUp != nil && warmUp.Duration.Duration <= 0 {
+		return fmt.Errorf("spec.warmUp.duration (%s) must be positive", warmUp.Duration.Duration)
+	}
+	if coolDown := spec.CoolDown; coolDown != nil && coolDown.Duration.Duration <= 0 {
+		return fmt.Errorf("spec.coolDown.duration (%s) must be positive", coolDown.Duration.Duration)
+	}
+
+	if schedule := spec.Schedule; schedule != nil {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return fmt.Errorf("spec.schedule.timeZone %q is invalid: %w", schedule.TimeZone, err)
+		}
+		if _, err := cron.ParseStandard(schedule.Cron); err != nil {
+			return fmt.Errorf("spec.schedule.cron %q is invalid: %w", schedule.Cron, err)
+		}
+	}
+
+	if spec.MaxReplicas != nil && spec.MinReplicas > *spec.MaxReplicas {
+		return fmt.Errorf("spec.minReplicas (%d) must not exceed spec.maxReplicas (%d)", spec.MinReplicas, *spec.MaxReplicas)
+	}
+	return nil
+}
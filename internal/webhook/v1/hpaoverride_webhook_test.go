@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	autoscalingxv1 "rrethy.io/horizontalpodautoscalerx/api/v1"
+)
+
+var _ = Describe("HPAOverride Webhook", func() {
+	var (
+		ctx       context.Context
+		obj       *autoscalingxv1.HPAOverride
+		oldObj    *autoscalingxv1.HPAOverride
+		validator HPAOverrideCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		obj = &autoscalingxv1.HPAOverride{
+			ObjectMeta: metav1.ObjectMeta{Name: "some-override", Namespace: "default"},
+			Spec: autoscalingxv1.HPAOverrideSpec{
+				OverrideSpec: autoscalingxv1.OverrideSpec{
+					MinReplicas: 20,
+					Duration:    metav1.Duration{Duration: 1 * time.Hour},
+					Time:        metav1.Now(),
+				},
+				HPATargetName: "myhpa",
+			},
+		}
+		oldObj = obj.DeepCopy()
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: "myhpa", Namespace: "default"},
+			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 50},
+		}
+		validator = HPAOverrideCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(hpa).Build()}
+	})
+
+	Context("When creating or updating HPAOverride under Validating Webhook", func() {
+		It("Should admit a valid HPAOverride", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a zero duration", func() {
+			obj.Spec.Duration = metav1.Duration{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.duration")))
+		})
+
+		It("Should deny a negative duration", func() {
+			obj.Spec.Duration = metav1.Duration{Duration: -1 * time.Hour}
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().To(MatchError(ContainSubstring("spec.duration")))
+		})
+
+		It("Should deny a negative lead time", func() {
+			obj.Spec.LeadTime = &autoscalingxv1.LeadTime{Duration: metav1.Duration{Duration: -1 * time.Minute}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.leadTime.duration")))
+
+			obj.Spec.LeadTime = &autoscalingxv1.LeadTime{FromStartupLatency: true}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a negative ttlAfterExpiry", func() {
+			obj.Spec.TTLAfterExpiry = &metav1.Duration{Duration: -1 * time.Minute}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.ttlAfterExpiry")))
+		})
+
+		It("Should deny a ramp without a positive duration", func() {
+			obj.Spec.WarmUp = &autoscalingxv1.Ramp{Duration: metav1.Duration{Duration: 10 * time.Minute}}
+			obj.Spec.CoolDown = &autoscalingxv1.Ramp{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.coolDown.duration")))
+
+			obj.Spec.CoolDown.Duration = metav1.Duration{Duration: 10 * time.Minute}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a minReplicas exceeding the maxReplicas of the target HPA", func() {
+			obj.Spec.MinReplicas = 51
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("maxReplicas")))
+		})
+
+		It("Should admit a minReplicas exceeding the maxReplicas of the target HPA if the override raises maxReplicas", func() {
+			obj.Spec.MinReplicas = 51
+			obj.Spec.MaxReplicas = ptr.To(int32(100))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a minReplicas exceeding the maxReplicas of the override", func() {
+			obj.Spec.MaxReplicas = ptr.To(int32(10))
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.maxReplicas")))
+		})
+
+		It("Should admit an HPAOverride whose target HPA does not exist yet", func() {
+			obj.Spec.HPATargetName = "some-other-hpa"
+			obj.Spec.MinReplicas = 51
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a minReplicas exceeding the maxReplicas of an HPA targeted by selector", func() {
+			hpax := &autoscalingxv1.HorizontalPodAutoscalerX{
+				ObjectMeta: metav1.ObjectMeta{Name: "myhpax", Namespace: "default", Labels: map[string]string{"tier": "web"}},
+				Spec:       autoscalingxv1.HorizontalPodAutoscalerXSpec{HPATargetName: "myhpa"},
+			}
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "myhpa", Namespace: "default"},
+				Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MaxReplicas: 50},
+			}
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(hpax, hpa).Build()
+			obj.Spec.HPATargetName = ""
+			obj.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}
+			obj.Spec.MinReplicas = 51
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("maxReplicas")))
+
+			obj.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "batch"}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny an invalid selector", func() {
+			obj.Spec.HPATargetName = ""
+			obj.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}}}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.selector")))
+		})
+
+		It("Should deny an invalid cron expression", func() {
+			obj.Spec.Time = metav1.Time{}
+			obj.Spec.Schedule = &autoscalingxv1.Schedule{Cron: "every day"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.schedule.cron")))
+		})
+
+		It("Should deny an invalid time zone", func() {
+			obj.Spec.Time = metav1.Time{}
+			obj.Spec.Schedule = &autoscalingxv1.Schedule{Cron: "0 * * * *", TimeZone: "Mars/Olympus_Mons"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().To(MatchError(ContainSubstring("spec.schedule.timeZone")))
+		})
+	})
+})